@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/rsa"
 	"fmt"
 	"net/http"
 	"os"
@@ -25,15 +26,25 @@ import (
 	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/model"
 	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/repository"
 	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/redis"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/docker"
 	"github.com/oneee-playground/r2d2-api-server/internal/infra/email"
 	"github.com/oneee-playground/r2d2-api-server/internal/infra/github"
 	httproute "github.com/oneee-playground/r2d2-api-server/internal/infra/http"
 	"github.com/oneee-playground/r2d2-api-server/internal/infra/http/handler"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/inmem"
 	jwt_token "github.com/oneee-playground/r2d2-api-server/internal/infra/jwt"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/outbox"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/rejudge"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/runner"
 	auth_module "github.com/oneee-playground/r2d2-api-server/internal/module/auth"
+	contest_module "github.com/oneee-playground/r2d2-api-server/internal/module/contest"
 	event_module "github.com/oneee-playground/r2d2-api-server/internal/module/event"
 	exec_module "github.com/oneee-playground/r2d2-api-server/internal/module/exec"
+	link_module "github.com/oneee-playground/r2d2-api-server/internal/module/link"
+	report_module "github.com/oneee-playground/r2d2-api-server/internal/module/report"
 	resource_module "github.com/oneee-playground/r2d2-api-server/internal/module/resource"
+	result_module "github.com/oneee-playground/r2d2-api-server/internal/module/result"
+	revision_module "github.com/oneee-playground/r2d2-api-server/internal/module/revision"
 	section_module "github.com/oneee-playground/r2d2-api-server/internal/module/section"
 	submission_module "github.com/oneee-playground/r2d2-api-server/internal/module/submission"
 	task_module "github.com/oneee-playground/r2d2-api-server/internal/module/task"
@@ -44,6 +55,13 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
+// listeningEventBus is implemented by every event bus backend.
+type listeningEventBus interface {
+	event.Publisher
+	event.Subscriber
+	Listen(ctx context.Context)
+}
+
 func main() {
 	ctx := context.Background()
 
@@ -60,11 +78,12 @@ func main() {
 	}
 
 	emailConfig := config.GetEmailConfig()
+	githubConfig := config.GetGitHubConfig()
 
 	// etc.
 	var (
 		tokenManager = jwt_token.NewManager(jwt.SigningMethodHS256, []byte(config.GetJWTConfig().Secret))
-		oauthClient  = github.NewClient(http.DefaultClient, logger, config.GetGitHubConfig().ClientID, config.GetGitHubConfig().ClientSecret)
+		oauthClient  = github.NewClient(http.DefaultClient, logger, githubConfig.ClientID, githubConfig.ClientSecret)
 		emailSender  = email.NewGomailSender(logger, email.GomailOptions{
 			Host:     emailConfig.Host,
 			Port:     emailConfig.Port,
@@ -74,6 +93,33 @@ func main() {
 		})
 	)
 
+	var appPrivateKey *rsa.PrivateKey
+
+	if githubConfig.AppID != 0 {
+		pem, err := os.ReadFile(githubConfig.AppPrivateKeyPath)
+		if err != nil {
+			logger.Panic("failed to read github app private key", zap.Error(err))
+		}
+
+		appPrivateKey, err = jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			logger.Panic("failed to parse github app private key", zap.Error(err))
+		}
+	}
+
+	appClient := github.NewAppClient(http.DefaultClient, logger, githubConfig.AppID, appPrivateKey)
+
+	rueidisOpts := rueidis.ClientOption{
+		InitAddress: []string{config.GetRedisConfig().Addr},
+		SelectDB:    config.GetRedisConfig().DBNum,
+	}
+
+	redisClient, err := rueidis.NewClient(rueidisOpts)
+	if err != nil {
+		logger.Panic("failed to initialize redis client", zap.Error(err))
+	}
+	defer redisClient.Close()
+
 	awsConfig := config.GetAWSConfig()
 
 	awsConf := aws.Config{
@@ -81,29 +127,122 @@ func main() {
 		Credentials: credentials.NewStaticCredentialsProvider(awsConfig.AccessKeyID, awsConfig.SecretAccessKey, ""),
 	}
 
-	lambdaClient := lambda.NewFromConfig(awsConf)
-	imageBuilder := lambda_module.NewLambdaImageBuilder(lambdaClient, logger)
-
 	sqsClient := sqs.NewFromConfig(awsConf)
 
-	jobQueue := sqs_module.NewSQSJobQueue(sqsClient, logger, awsConfig.SQSConfig.JobQueueURL)
-	eventBus := sqs_module.NewSQSEventBus(sqsClient, logger, map[event.Topic]sqs_module.QueueConfig{
-		event.TopicBuild: {
-			URL:          awsConfig.SQSConfig.BuildEventQueueURL,
-			PollInterval: awsConfig.SQSConfig.PollInterval,
-		},
-		event.TopicSubmission: {
-			URL:          awsConfig.SQSConfig.SubmissionEventQueueURL,
-			PollInterval: awsConfig.SQSConfig.PollInterval,
-		},
-		event.TopicTest: {
-			URL:          awsConfig.SQSConfig.TestEventQueueURL,
-			PollInterval: awsConfig.SQSConfig.PollInterval,
-		},
-	})
+	var eventBus listeningEventBus
+
+	switch eventBusConfig := config.GetEventBusConfig(); eventBusConfig.Kind {
+	case config.EventBusMemory:
+		eventBus = inmem.NewInMemoryEventBus(logger,
+			inmem.EventBusOptions{
+				Workers:    eventBusConfig.Workers,
+				BufferSize: eventBusConfig.BufferSize,
+			},
+			event.TopicBuild, event.TopicSubmission, event.TopicTest, event.TopicPush,
+		)
+	case config.EventBusSQS:
+		eventBus = sqs_module.NewSQSEventBus(sqsClient, logger, map[event.Topic]sqs_module.QueueConfig{
+			event.TopicBuild: {
+				URL:                 awsConfig.SQSConfig.BuildEventQueueURL,
+				PollInterval:        awsConfig.SQSConfig.PollInterval,
+				WaitTimeSeconds:     awsConfig.SQSConfig.WaitTimeSeconds,
+				MaxNumberOfMessages: awsConfig.SQSConfig.MaxNumberOfMessages,
+				VisibilityTimeout:   awsConfig.SQSConfig.VisibilityTimeout,
+				Workers:             awsConfig.SQSConfig.Workers,
+				DeadLetterURL:       awsConfig.SQSConfig.DeadLetterQueueURL,
+				MaxReceiveCount:     awsConfig.SQSConfig.MaxReceiveCount,
+				BaseBackoff:         awsConfig.SQSConfig.BaseBackoff,
+				MaxBackoff:          awsConfig.SQSConfig.MaxBackoff,
+			},
+			event.TopicSubmission: {
+				URL:                 awsConfig.SQSConfig.SubmissionEventQueueURL,
+				PollInterval:        awsConfig.SQSConfig.PollInterval,
+				WaitTimeSeconds:     awsConfig.SQSConfig.WaitTimeSeconds,
+				MaxNumberOfMessages: awsConfig.SQSConfig.MaxNumberOfMessages,
+				VisibilityTimeout:   awsConfig.SQSConfig.VisibilityTimeout,
+				Workers:             awsConfig.SQSConfig.Workers,
+				DeadLetterURL:       awsConfig.SQSConfig.DeadLetterQueueURL,
+				MaxReceiveCount:     awsConfig.SQSConfig.MaxReceiveCount,
+				BaseBackoff:         awsConfig.SQSConfig.BaseBackoff,
+				MaxBackoff:          awsConfig.SQSConfig.MaxBackoff,
+			},
+			event.TopicTest: {
+				URL:                 awsConfig.SQSConfig.TestEventQueueURL,
+				PollInterval:        awsConfig.SQSConfig.PollInterval,
+				WaitTimeSeconds:     awsConfig.SQSConfig.WaitTimeSeconds,
+				MaxNumberOfMessages: awsConfig.SQSConfig.MaxNumberOfMessages,
+				VisibilityTimeout:   awsConfig.SQSConfig.VisibilityTimeout,
+				Workers:             awsConfig.SQSConfig.Workers,
+				DeadLetterURL:       awsConfig.SQSConfig.DeadLetterQueueURL,
+				MaxReceiveCount:     awsConfig.SQSConfig.MaxReceiveCount,
+				BaseBackoff:         awsConfig.SQSConfig.BaseBackoff,
+				MaxBackoff:          awsConfig.SQSConfig.MaxBackoff,
+			},
+			event.TopicPush: {
+				URL:                 awsConfig.SQSConfig.PushEventQueueURL,
+				PollInterval:        awsConfig.SQSConfig.PollInterval,
+				WaitTimeSeconds:     awsConfig.SQSConfig.WaitTimeSeconds,
+				MaxNumberOfMessages: awsConfig.SQSConfig.MaxNumberOfMessages,
+				VisibilityTimeout:   awsConfig.SQSConfig.VisibilityTimeout,
+				Workers:             awsConfig.SQSConfig.Workers,
+				DeadLetterURL:       awsConfig.SQSConfig.DeadLetterQueueURL,
+				MaxReceiveCount:     awsConfig.SQSConfig.MaxReceiveCount,
+				BaseBackoff:         awsConfig.SQSConfig.BaseBackoff,
+				MaxBackoff:          awsConfig.SQSConfig.MaxBackoff,
+			},
+		})
+	case config.EventBusRedis:
+		streamConfig := config.GetRedisConfig().StreamConfig
+
+		eventBus = redis.NewRedisStreamEventBus(redisClient, logger,
+			redis.StreamOptions{
+				Group:        streamConfig.Group,
+				Consumer:     streamConfig.Consumer,
+				BatchSize:    streamConfig.BatchSize,
+				BlockTimeout: streamConfig.BlockTimeout,
+				ClaimMinIdle: streamConfig.ClaimMinIdle,
+			},
+			event.TopicBuild, event.TopicSubmission, event.TopicTest, event.TopicPush,
+		)
+	}
 
 	go eventBus.Listen(ctx)
 
+	var imageBuilder exec_module.ImageBuilder
+
+	switch builderConfig := config.GetBuilderConfig(); builderConfig.Kind {
+	case config.BuilderDocker:
+		imageBuilder = docker.NewDockerImageBuilder(eventBus, logger, docker.BuilderOptions{
+			WorkDir:     builderConfig.WorkDir,
+			ImagePrefix: builderConfig.ImagePrefix,
+			Timeout:     builderConfig.Timeout,
+		})
+	case config.BuilderLambda:
+		lambdaClient := lambda.NewFromConfig(awsConf)
+		imageBuilder = lambda_module.NewLambdaImageBuilder(lambdaClient, logger)
+	}
+
+	var jobQueue exec_module.JobQueue
+
+	switch runnerConfig := config.GetRunnerConfig(); runnerConfig.Kind {
+	case config.RunnerDocker:
+		jobRunner := runner.NewLocalJobRunner(eventBus, logger, runner.RunnerOptions{
+			Workers:         runnerConfig.Workers,
+			QueueSize:       100,
+			ImagePrefix:     config.GetBuilderConfig().ImagePrefix,
+			StartTimeout:    runnerConfig.StartTimeout,
+			RequestTimeout:  runnerConfig.RequestTimeout,
+			LoadDuration:    runnerConfig.LoadDuration,
+			MaxFailureRatio: runnerConfig.MaxFailureRatio,
+		})
+
+		go jobRunner.Run(ctx)
+
+		jobQueue = jobRunner
+	case config.RunnerSQS:
+		jobQueue = sqs_module.NewSQSJobQueue(sqsClient, logger, awsConfig.SQSConfig.JobQueueURL)
+	}
+
 	mysqlConf := config.GetMYSQLConfig()
 
 	entClient, err := model.Open("mysql", fmt.Sprintf("root:%s@tcp(%s)/r2d2?parseTime=true", mysqlConf.Pass, mysqlConf.Addr))
@@ -125,19 +264,13 @@ func main() {
 		submissionRepo = repository.NewSubmissionRepository(datasource)
 		taskRepo       = repository.NewTaskRepository(datasource)
 		userRepo       = repository.NewUserRepository(datasource)
+		outboxRepo     = repository.NewOutboxRepository(datasource)
+		resultRepo     = repository.NewResultRepository(datasource)
+		revisionRepo   = repository.NewTaskRevisionRepository(datasource)
+		contestRepo    = repository.NewContestRepository(datasource)
+		linkRepo       = repository.NewRepositoryLinkRepository(datasource)
 	)
 
-	rueidisOpts := rueidis.ClientOption{
-		InitAddress: []string{config.GetRedisConfig().Addr},
-		SelectDB:    config.GetRedisConfig().DBNum,
-	}
-
-	redisClient, err := rueidis.NewClient(rueidisOpts)
-	if err != nil {
-		logger.Panic("failed to initialize redis client", zap.Error(err))
-	}
-	defer redisClient.Close()
-
 	lock, err := rueidislock.NewLocker(rueidislock.LockerOption{ClientOption: rueidisOpts})
 	if err != nil {
 		logger.Panic("failed to initizlize redis lock client", zap.Error(err))
@@ -146,39 +279,92 @@ func main() {
 
 	txLocker := redis.NewLocker(lock)
 	execContextStorage := redis.NewExecContextStroage(redisClient)
+	rateLimiter := redis.NewRateLimiter(redisClient)
+
+	limitConfig := config.GetSubmissionLimitConfig()
+	submissionLimits := submission_module.Limits{
+		TaskHourly: limitConfig.TaskHourly,
+		TaskDaily:  limitConfig.TaskDaily,
+		Hourly:     limitConfig.Hourly,
+		Daily:      limitConfig.Daily,
+	}
+
+	outboxRelay := outbox.NewRelay(outboxRepo, eventBus, txLocker, logger, outbox.RelayOptions{
+		Interval:  config.GetEventBusConfig().OutboxRelayInterval,
+		BatchSize: config.GetEventBusConfig().OutboxBatchSize,
+	})
+
+	go outboxRelay.Run(ctx)
+
+	rejudgeDispatcher := rejudge.NewDispatcher(submissionRepo, outboxRepo, txLocker, logger, rejudge.DispatcherOptions{
+		Interval:  config.GetRejudgeConfig().Interval,
+		BatchSize: config.GetRejudgeConfig().BatchSize,
+	})
+
+	go rejudgeDispatcher.Run(ctx)
 
 	var (
 		authUsecase       = auth_module.NewAuthUsecase(oauthClient, tokenManager, userRepo, txLocker)
 		resourceUsecase   = resource_module.NewResourceUsecase(resourceRepo, taskRepo, txLocker)
 		sectionUsecase    = section_module.NewSectionUsecase(sectionRepo, taskRepo, txLocker)
-		submissionUsecase = submission_module.NewSubmissionUsecase(taskRepo, submissionRepo, eventRepo, eventBus, txLocker)
-		taskUsecase       = task_module.NewTaskUsecase(taskRepo, txLocker)
-		userUsecase       = user_module.NewUserUsecase(userRepo)
+		submissionUsecase = submission_module.NewSubmissionUsecase(taskRepo, submissionRepo, revisionRepo, contestRepo, userRepo, eventRepo, outboxRepo, oauthClient, appClient, rateLimiter, submissionLimits, txLocker)
+		taskUsecase       = task_module.NewTaskUsecase(taskRepo, submissionRepo, sectionRepo, resourceRepo, revisionRepo, outboxRepo, txLocker)
+		userUsecase       = user_module.NewUserUsecase(userRepo, appClient)
 		eventUsecase      = event_module.NewEventUsecase(eventRepo)
+		resultUsecase     = result_module.NewResultUsecase(submissionRepo, resultRepo)
+		revisionUsecase   = revision_module.NewRevisionUsecase(taskRepo, revisionRepo)
+		contestUsecase    = contest_module.NewContestUsecase(contestRepo, taskRepo, submissionRepo, txLocker)
+		linkUsecase       = link_module.NewLinkUsecase(taskRepo, linkRepo)
+		webhookUsecase    = link_module.NewWebhookUsecase(githubConfig.WebhookSecret, outboxRepo)
 
-		execEventHandler  = exec_module.NewEventHandler(submissionRepo, sectionRepo, resourceRepo, eventBus, jobQueue, imageBuilder, execContextStorage)
-		eventEventHandler = event_module.NewEventHandler(emailSender, userRepo, eventRepo)
+		execEventHandler   = exec_module.NewEventHandler(submissionRepo, sectionRepo, resourceRepo, revisionRepo, userRepo, eventBus, jobQueue, imageBuilder, execContextStorage, appClient)
+		eventEventHandler  = event_module.NewEventHandler(emailSender, userRepo, eventRepo)
+		resultEventHandler = result_module.NewEventHandler(submissionRepo, resultRepo)
+		linkEventHandler   = link_module.NewEventHandler(linkRepo, submissionUsecase)
+		reportEventHandler = report_module.NewEventHandler(submissionRepo, userRepo, appClient, config.GetServerConfig().PublicURL)
 	)
 
-	if err := execEventHandler.Register(ctx, eventBus); err != nil {
+	// Events can be delivered more than once. Make sure every handler processes it once.
+	subscriber := &event.IdempotentSubscriber{
+		Subscriber: eventBus,
+		Ledger:     redis.NewLedger(redisClient, config.GetEventBusConfig().LedgerProcessingTTL, config.GetEventBusConfig().LedgerTTL),
+	}
+
+	// Results should be stored before exec event handler announces the test is over,
+	// since scoring depends on them. Handlers of a topic are called in registered order.
+	if err := resultEventHandler.Register(ctx, subscriber); err != nil {
+		logger.Panic("registering result event handler failed", zap.Error(err))
+	}
+	if err := execEventHandler.Register(ctx, subscriber); err != nil {
 		logger.Panic("registering exec event handler failed", zap.Error(err))
 	}
-	if err := eventEventHandler.Register(ctx, eventBus); err != nil {
+	if err := eventEventHandler.Register(ctx, subscriber); err != nil {
 		logger.Panic("registering event event handler failed", zap.Error(err))
 	}
+	if err := linkEventHandler.Register(ctx, subscriber); err != nil {
+		logger.Panic("registering link event handler failed", zap.Error(err))
+	}
+	if err := reportEventHandler.Register(ctx, subscriber); err != nil {
+		logger.Panic("registering report event handler failed", zap.Error(err))
+	}
 
 	router := &httproute.Router{
 		Engine:            gin.New(),
+		ContestHandler:    handler.NewContestHandler(contestUsecase),
 		TokenDecoder:      tokenManager,
 		RequestLogger:     logger,
 		ErrorLogger:       logger,
 		EventHandler:      handler.NewEventHandler(eventUsecase),
+		LinkHandler:       handler.NewRepositoryLinkHandler(linkUsecase),
 		ResourceHandler:   handler.NewResourceHandler(resourceUsecase),
+		ResultHandler:     handler.NewResultHandler(resultUsecase),
+		RevisionHandler:   handler.NewRevisionHandler(revisionUsecase),
 		SectionHandler:    handler.NewSectionHandler(sectionUsecase),
 		SubmissionHandler: handler.NewSubmissionHandler(submissionUsecase),
 		TaskHandler:       handler.NewTaskHandler(taskUsecase),
 		UserHandler:       handler.NewUserHandler(userUsecase),
 		AuthHandler:       handler.NewAuthHandler(authUsecase),
+		WebhookHandler:    handler.NewWebhookHandler(webhookUsecase),
 	}
 	router.Build()
 
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+)
+
+//go:generate mockgen -source=contest.go -destination=../../test/mocks/contest.go -package=mocks
+
+// Contest is a time-boxed group of tasks.
+// Tasks in any contest only accept submissions while one of them is running.
+type Contest struct {
+	ID      uuid.UUID
+	Title   string
+	StartAt time.Time
+	EndAt   time.Time
+	// FreezeAt is when the scoreboard stops updating until the contest ends.
+	// It is nil if the scoreboard never freezes.
+	FreezeAt *time.Time
+
+	// Tasks are ordered as shown in the contest.
+	Tasks []Task
+}
+
+// IsRunning reports whether t is in [StartAt, EndAt).
+func (c Contest) IsRunning(t time.Time) bool {
+	return !t.Before(c.StartAt) && t.Before(c.EndAt)
+}
+
+// IsFrozen reports whether the scoreboard is frozen at t.
+func (c Contest) IsFrozen(t time.Time) bool {
+	return c.FreezeAt != nil && !t.Before(*c.FreezeAt) && t.Before(c.EndAt)
+}
+
+type ContestUsecase interface {
+	GetList(ctx context.Context) (out *dto.ContestListOutput, err error)
+	GetContest(ctx context.Context, in dto.IDInput) (out *dto.ContestOutput, err error)
+	CreateContest(ctx context.Context, in dto.ContestInput) (out *dto.IDOutput, err error)
+	UpdateContest(ctx context.Context, in dto.UpdateContestInput) (err error)
+	GetScoreboard(ctx context.Context, in dto.IDInput) (out *dto.ScoreboardOutput, err error)
+}
+
+var (
+	ErrContestNotFound = errors.New("contest not found")
+)
+
+type ContestRepository interface {
+	// FetchAll returns contests ordered by start time desc.
+	// Contests will not include Tasks field.
+	FetchAll(ctx context.Context) ([]Contest, error)
+	// FetchByID returns the contest including Tasks field.
+	FetchByID(ctx context.Context, id uuid.UUID) (Contest, error)
+	// FetchAllByTaskID returns contests which include the task.
+	// Contests will not include Tasks field.
+	FetchAllByTaskID(ctx context.Context, taskID uuid.UUID) ([]Contest, error)
+	// Create creates the contest with its tasks. Only ID of the tasks is used.
+	Create(ctx context.Context, contest Contest) error
+	// Update updates the contest and replaces its tasks. Only ID of the tasks is used.
+	Update(ctx context.Context, contest Contest) error
+}
@@ -0,0 +1,57 @@
+package dto
+
+import "time"
+
+type ContestListElem struct {
+	ID      string    `json:"id" binding:"uuid"`
+	Title   string    `json:"title"`
+	StartAt time.Time `json:"startAt"`
+	EndAt   time.Time `json:"endAt"`
+}
+
+type ContestListOutput []ContestListElem
+
+type ContestOutput struct {
+	ID       string     `json:"id" binding:"uuid"`
+	Title    string     `json:"title"`
+	StartAt  time.Time  `json:"startAt"`
+	EndAt    time.Time  `json:"endAt"`
+	FreezeAt *time.Time `json:"freezeAt"`
+	// Tasks are ordered as shown in the contest.
+	Tasks []TaskListElem `json:"tasks"`
+}
+
+type ContestInput struct {
+	Title   string    `json:"title" binding:"required"`
+	StartAt time.Time `json:"startAt" binding:"required"`
+	EndAt   time.Time `json:"endAt" binding:"required,gtfield=StartAt"`
+	// FreezeAt is optional. It should be between StartAt and EndAt.
+	FreezeAt *time.Time `json:"freezeAt"`
+	// TaskIDs are ordered as shown in the contest.
+	TaskIDs []string `json:"taskIDs" binding:"required,min=1,max=255,unique,dive,uuid"`
+}
+
+type UpdateContestInput struct {
+	IDInput
+	ContestInput
+}
+
+type ScoreboardTask struct {
+	TaskID string `json:"taskID" binding:"uuid"`
+	// Score is the best score of the task. It is nil if not scored.
+	Score *uint64 `json:"score"`
+}
+
+type ScoreboardElem struct {
+	Rank  int      `json:"rank"`
+	Score uint64   `json:"score"`
+	User  UserInfo `json:"user"`
+	// Tasks are ordered as shown in the contest.
+	Tasks []ScoreboardTask `json:"tasks"`
+}
+
+type ScoreboardOutput struct {
+	// Frozen is true if submissions after the freeze are not reflected.
+	Frozen    bool             `json:"frozen"`
+	Standings []ScoreboardElem `json:"standings"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+type RepositoryLinkInput struct {
+	IDInput
+	Repository string `json:"repository" binding:"required"`
+	Branch     string `json:"branch" binding:"required"`
+}
+
+type RepositoryLinkOutput struct {
+	Repository string `json:"repository"`
+	Branch     string `json:"branch"`
+}
+
+type GitHubWebhookInput struct {
+	// Event is the value of header "X-GitHub-Event".
+	Event string
+	// DeliveryID is the value of header "X-GitHub-Delivery".
+	DeliveryID string
+	// Signature is the value of header "X-Hub-Signature-256".
+	Signature string
+	Payload   []byte
+}
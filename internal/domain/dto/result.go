@@ -0,0 +1,25 @@
+package dto
+
+type LatencyInfo struct {
+	P50 int64 `json:"p50"`
+	P95 int64 `json:"p95"`
+	P99 int64 `json:"p99"`
+}
+
+type ResultListElem struct {
+	SectionID    string `json:"sectionID" binding:"uuid"`
+	SectionTitle string `json:"sectionTitle"`
+	SectionIndex uint8  `json:"sectionIndex"`
+	SectionType  string `json:"sectionType"`
+
+	Passed bool   `json:"passed"`
+	Error  string `json:"error"`
+	Score  uint64 `json:"score"`
+
+	TotalRequests  int `json:"totalRequests"`
+	FailedRequests int `json:"failedRequests"`
+	// Latency is in milliseconds.
+	Latency LatencyInfo `json:"latency"`
+}
+
+type ResultListOutput []ResultListElem
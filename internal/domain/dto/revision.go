@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+type RevisionListElem struct {
+	ID            string    `json:"id" binding:"uuid"`
+	Number        uint32    `json:"number"`
+	CreatedAt     time.Time `json:"createdAt"`
+	SectionCount  int       `json:"sectionCount"`
+	ResourceCount int       `json:"resourceCount"`
+}
+
+type RevisionListOutput []RevisionListElem
+
+type RevisionPair struct {
+	From string `form:"from" binding:"required,uuid"`
+	To   string `form:"to" binding:"required,uuid"`
+}
+
+type RevisionDiffInput struct {
+	IDInput
+	RevisionPair
+}
+
+type FieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+type DiffEntry struct {
+	// Kind is one of ADDED, REMOVED and CHANGED.
+	Kind string `json:"kind"`
+	// Target is ID of the section, or name of the resource.
+	Target  string        `json:"target"`
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+type RevisionDiffOutput struct {
+	From      uint32      `json:"from"`
+	To        uint32      `json:"to"`
+	Sections  []DiffEntry `json:"sections"`
+	Resources []DiffEntry `json:"resources"`
+}
@@ -18,7 +18,10 @@ type SubmissionListElem struct {
 	Timestamp time.Time `json:"timestamp"`
 	SourceURL string    `json:"sourceURL"`
 	IsDone    bool      `json:"isDone"`
+	Score     *uint64   `json:"score"`
 	User      UserInfo  `json:"user"`
+	// RejudgeOf is ID of the original submission if it is a re-run.
+	RejudgeOf *string `json:"rejudgeOf"`
 }
 
 type SubmissionListOutput []SubmissionListElem
@@ -39,3 +42,60 @@ type SubmissionDecisionInput struct {
 	Action string `json:"action" binding:"required" validate:"submission_action"`
 	Extra  string `json:"extra" binding:"required"`
 }
+
+type PendingSubmissionListInput struct {
+	TaskID string `form:"taskID" binding:"omitempty,uuid"`
+	UserID string `form:"userID" binding:"omitempty,uuid"`
+	// MinAge filters submissions waited at least given seconds.
+	MinAge int `form:"minAge" binding:"min=0"`
+}
+
+type PendingSubmissionElem struct {
+	ID        string    `json:"id" binding:"uuid"`
+	TaskID    string    `json:"taskID" binding:"uuid"`
+	Timestamp time.Time `json:"timestamp"`
+	SourceURL string    `json:"sourceURL"`
+	User      UserInfo  `json:"user"`
+}
+
+type PendingSubmissionListOutput []PendingSubmissionElem
+
+type BulkDecisionInput struct {
+	SubmissionIDs []string `json:"submissionIDs" binding:"required,min=1,max=100,unique,dive,uuid"`
+	Action        string   `json:"action" binding:"required" validate:"submission_action"`
+	Extra         string   `json:"extra" binding:"required"`
+}
+
+type BulkDecisionOutput struct {
+	Decided []string `json:"decided"`
+	// Skipped holds submissions which were not found or already done.
+	Skipped []string `json:"skipped"`
+}
+
+type RejudgeInput struct {
+	IDInput
+	// LatestPerUser only re-runs the latest submission of each user.
+	LatestPerUser bool       `json:"latestPerUser"`
+	From          *time.Time `json:"from"`
+	To            *time.Time `json:"to"`
+}
+
+type RejudgeOutput struct {
+	// Count is number of re-runs queued.
+	Count int `json:"count"`
+}
+
+type LeaderboardElem struct {
+	Rank         int       `json:"rank"`
+	SubmissionID string    `json:"submissionID" binding:"uuid"`
+	Score        uint64    `json:"score"`
+	Timestamp    time.Time `json:"timestamp"`
+	User         UserInfo  `json:"user"`
+}
+
+type LeaderboardOutput []LeaderboardElem
+
+type RateLimitDetails struct {
+	// RetryAfter is seconds to wait before submitting again.
+	RetryAfter int `json:"retryAfter"`
+}
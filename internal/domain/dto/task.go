@@ -8,15 +8,38 @@ type TaskListElem struct {
 type TaskListOutput []TaskListElem
 
 type TaskOutput struct {
-	ID          string `json:"id" binding:"uuid"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Stage       string `json:"stage"`
+	ID              string          `json:"id" binding:"uuid"`
+	Title           string          `json:"title"`
+	Description     string          `json:"description"`
+	Stage           string          `json:"stage"`
+	ApprovalPolicy  ApprovalPolicy  `json:"approvalPolicy"`
+	SubmissionLimit SubmissionLimit `json:"submissionLimit"`
+}
+
+type ApprovalPolicy struct {
+	Rule        string   `json:"rule" binding:"required" validate:"approval_rule"`
+	AllowedOrgs []string `json:"allowedOrgs"`
+}
+
+type ApprovalPolicyInput struct {
+	IDInput
+	ApprovalPolicy
+}
+
+// SubmissionLimit is per-task limits of each user. Null uses global limits, and zero means unlimited.
+type SubmissionLimit struct {
+	Hourly *uint64 `json:"hourly"`
+	Daily  *uint64 `json:"daily"`
+}
+
+type SubmissionLimitInput struct {
+	IDInput
+	SubmissionLimit
 }
 
 type TaskInput struct {
-	Title       string `json:"title" binding:"required"`
-	Description string `json:"description" binding:"required"`
+	Title       string `json:"title" yaml:"title" binding:"required"`
+	Description string `json:"description" yaml:"description" binding:"required"`
 }
 
 type UpdateTaskInput struct {
@@ -24,7 +47,56 @@ type UpdateTaskInput struct {
 	TaskInput
 }
 
+type CloneTaskInput struct {
+	IDInput
+	// Title of the new task. Original title is used if empty.
+	Title string `json:"title"`
+}
+
 type TaskStageInput struct {
 	IDInput
 	Stage string `json:"stage" binding:"required" validate:"task_stage"`
 }
+
+type ReadinessProblem struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Target is name of the resource or ID of the section which has problem.
+	// It is empty if the problem is about the whole task.
+	Target string `json:"target,omitempty"`
+}
+
+type ReadinessOutput struct {
+	Ready    bool               `json:"ready"`
+	Problems []ReadinessProblem `json:"problems"`
+}
+
+type BundleSection struct {
+	Title       string `json:"title" yaml:"title" binding:"required"`
+	Description string `json:"description" yaml:"description" binding:"required"`
+	Type        string `json:"type" yaml:"type" binding:"required" validate:"section_type"`
+	RPM         uint64 `json:"rpm" yaml:"rpm"`
+	Example     string `json:"example" yaml:"example"`
+}
+
+type BundleResource struct {
+	Image     string  `json:"image" yaml:"image" binding:"required"`
+	Name      string  `json:"name" yaml:"name" binding:"required"`
+	Port      uint16  `json:"port" yaml:"port" binding:"required"`
+	CPU       float64 `json:"cpu" yaml:"cpu" binding:"required"`
+	Memory    uint64  `json:"memory" yaml:"memory" binding:"required"`
+	IsPrimary bool    `json:"isPrimary" yaml:"isPrimary"`
+}
+
+// TaskBundle is portable definition of a task.
+type TaskBundle struct {
+	Version int       `json:"version" yaml:"version" binding:"required"`
+	Task    TaskInput `json:"task" yaml:"task" binding:"required"`
+	// Sections are ordered by its index.
+	Sections  []BundleSection  `json:"sections" yaml:"sections" binding:"dive"`
+	Resources []BundleResource `json:"resources" yaml:"resources" binding:"dive"`
+}
+
+type ExportFormat struct {
+	Format string `form:"format" binding:"omitempty,oneof=json yaml"`
+}
@@ -7,3 +7,12 @@ type SignInInput struct {
 type AccessTokenOutput struct {
 	Token string `json:"token"`
 }
+
+type LimitExemptionInput struct {
+	IDInput
+	Exempt *bool `json:"exempt" binding:"required"`
+}
+
+type InstallationInput struct {
+	InstallationID int64 `json:"installationID" binding:"required"`
+}
@@ -26,6 +26,9 @@ const (
 	KindCancel       EventKind = "CANCEL"
 )
 
+// FinishingKinds are kinds of events after which the submission is no longer executed.
+var FinishingKinds = []EventKind{KindReject, KindCancel, KindBuildFail, KindTestFail, KindTestSuccess}
+
 type Event struct {
 	ID uuid.UUID
 
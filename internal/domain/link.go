@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+)
+
+//go:generate mockgen -source=link.go -destination=../../test/mocks/link.go -package=mocks
+
+// RepositoryLink makes pushes to the branch submitted to the task automatically.
+type RepositoryLink struct {
+	ID uuid.UUID
+
+	// Github Repository name, e.g. "oneee-playground/empty"
+	Repository string
+	Branch     string
+
+	UserID uuid.UUID
+	TaskID uuid.UUID
+}
+
+type RepositoryLinkUsecase interface {
+	GetLink(ctx context.Context, in dto.IDInput) (out *dto.RepositoryLinkOutput, err error)
+	SetLink(ctx context.Context, in dto.RepositoryLinkInput) (err error)
+	DeleteLink(ctx context.Context, in dto.IDInput) (err error)
+}
+
+type WebhookUsecase interface {
+	ReceiveGitHub(ctx context.Context, in dto.GitHubWebhookInput) (err error)
+}
+
+var (
+	ErrRepositoryLinkNotFound = errors.New("repository link not found")
+)
+
+type RepositoryLinkRepository interface {
+	FetchByUserAndTask(ctx context.Context, userID, taskID uuid.UUID) (RepositoryLink, error)
+	// FetchAllByBranch returns links following the branch of the repository.
+	FetchAllByBranch(ctx context.Context, repository, branch string) ([]RepositoryLink, error)
+	Create(ctx context.Context, link RepositoryLink) error
+	Update(ctx context.Context, link RepositoryLink) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+)
+
+//go:generate mockgen -source=result.go -destination=../../test/mocks/result.go -package=mocks
+
+// Result is the test result of a section in a submission.
+type Result struct {
+	ID     uuid.UUID
+	Passed bool
+	// Error describes why the section failed. Empty if passed.
+	Error string
+
+	TotalRequests  int
+	FailedRequests int
+
+	// Latency percentiles of the requests sent.
+	P50 time.Duration
+	P95 time.Duration
+	P99 time.Duration
+
+	SubmissionID uuid.UUID
+	Submission   *Submission
+
+	SectionID uuid.UUID
+	Section   *Section
+}
+
+type ResultUsecase interface {
+	GetAllFromSubmission(ctx context.Context, in dto.SubmissionIDInput) (out *dto.ResultListOutput, err error)
+}
+
+type ResultRepository interface {
+	// FetchAllBySubmissionID returns results ordered by index of the section.
+	// Results will include Section field from the revision the submission was judged against.
+	// It is nil if the section is unknown, and those results come last.
+	FetchAllBySubmissionID(ctx context.Context, id uuid.UUID) ([]Result, error)
+	CreateBulk(ctx context.Context, results []Result) error
+}
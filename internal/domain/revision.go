@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+)
+
+//go:generate mockgen -source=revision.go -destination=../../test/mocks/revision.go -package=mocks
+
+// TaskRevision is an immutable snapshot of task's sections and resources.
+// It is taken every time the task becomes available.
+type TaskRevision struct {
+	ID uuid.UUID
+	// Number starts from 1 and increases by 1 in the task.
+	Number    uint32
+	CreatedAt time.Time
+
+	// Sections are ordered by its index.
+	Sections  []Section
+	Resources []Resource
+
+	TaskID uuid.UUID
+	Task   *Task
+}
+
+type RevisionUsecase interface {
+	GetList(ctx context.Context, in dto.IDInput) (out *dto.RevisionListOutput, err error)
+	Diff(ctx context.Context, in dto.RevisionDiffInput) (out *dto.RevisionDiffOutput, err error)
+}
+
+var (
+	ErrRevisionNotFound = errors.New("revision not found")
+)
+
+type TaskRevisionRepository interface {
+	// FetchAllByTaskID returns revisions ordered by number desc.
+	FetchAllByTaskID(ctx context.Context, taskID uuid.UUID) ([]TaskRevision, error)
+	FetchByID(ctx context.Context, id uuid.UUID) (TaskRevision, error)
+	// FetchLatest returns the revision with the highest number.
+	FetchLatest(ctx context.Context, taskID uuid.UUID) (TaskRevision, error)
+	Create(ctx context.Context, revision TaskRevision) error
+}
@@ -21,25 +21,54 @@ const (
 type Submission struct {
 	ID        uuid.UUID
 	Timestamp time.Time
-	IsDone    bool
+	// IsDone is set once the submission is decided or cancelled.
+	// Approved submissions are still executed after it is set.
+	IsDone bool
+	// IsCancelled is set if the submission is cancelled. It should not be executed.
+	IsCancelled bool
 
 	// Github Repository name, e.g. "oneee-playground/empty"
 	Repository string
 	// Commit hash of the source.
 	CommitHash string
+	// Score is total points from the test. It is nil until tested.
+	Score *uint64
 
 	UserID uuid.UUID
 	User   *User
 
 	TaskID uuid.UUID
 	Task   *Task
+
+	// RevisionID is the revision of the task when submitted.
+	// It is nil if the task had no revision.
+	RevisionID *uuid.UUID
+
+	// RejudgeOf is the original submission if it is a re-run.
+	// Re-runs are judged again as new submissions, so results of the original are kept.
+	RejudgeOf *uuid.UUID
+}
+
+// PendingFilter narrows down submissions waiting for approval.
+// Nil fields are not used.
+type PendingFilter struct {
+	TaskID *uuid.UUID
+	UserID *uuid.UUID
+	// SubmittedBefore filters submissions waited since the time.
+	SubmittedBefore *time.Time
 }
 
 type SubmissionUsecase interface {
 	GetList(ctx context.Context, in dto.SubmissionListInput) (out *dto.SubmissionListOutput, err error)
 	Submit(ctx context.Context, in dto.SubmissionInput) (out *dto.IDOutput, err error)
 	DecideApproval(ctx context.Context, in dto.SubmissionDecisionInput) (err error)
+	GetPendingList(ctx context.Context, in dto.PendingSubmissionListInput) (out *dto.PendingSubmissionListOutput, err error)
+	// DecideBulk decides approval of the submissions at once. Submissions already done are skipped.
+	DecideBulk(ctx context.Context, in dto.BulkDecisionInput) (out *dto.BulkDecisionOutput, err error)
+	// Rejudge queues re-runs of approved submissions of the task.
+	Rejudge(ctx context.Context, in dto.RejudgeInput) (out *dto.RejudgeOutput, err error)
 	Cancel(ctx context.Context, in dto.SubmissionIDInput) (err error)
+	GetLeaderboard(ctx context.Context, in dto.IDInput) (out *dto.LeaderboardOutput, err error)
 }
 
 var (
@@ -53,6 +82,27 @@ type SubmissionRepository interface {
 	FetchPaginated(ctx context.Context, taskID uuid.UUID, offset, limit int) ([]Submission, error)
 	Create(ctx context.Context, submission Submission) error
 	Update(ctx context.Context, submission Submission) error
+	// UndoneExists excludes queued rejudges.
 	UndoneExists(ctx context.Context, taskID, userID uuid.UUID) (bool, error)
+	// FetchAllPending returns undone submissions which only have SUBMIT event.
+	// It is ordered by timestamp asc.
+	// Submissions will include User field.
+	FetchAllPending(ctx context.Context, filter PendingFilter) ([]Submission, error)
+	// FetchAllApproved returns approved submissions of the task, which are not re-runs.
+	// Submissions are filtered by timestamp if from or to is not nil.
+	// It is ordered by timestamp desc.
+	FetchAllApproved(ctx context.Context, taskID uuid.UUID, from, to *time.Time) ([]Submission, error)
+	// FetchQueuedRejudges returns re-runs waiting to be dispatched.
+	// It is ordered by timestamp asc.
+	FetchQueuedRejudges(ctx context.Context, limit int) ([]Submission, error)
+	FetchAllUndone(ctx context.Context, taskID uuid.UUID) ([]Submission, error)
+	// FetchAllRunning returns approved submissions of the task which are still executed.
+	FetchAllRunning(ctx context.Context, taskID uuid.UUID) ([]Submission, error)
 	FetchByID(ctx context.Context, id uuid.UUID) (Submission, error)
+	// FetchAllScored returns scored submissions of the task.
+	// It is ordered by score desc, then timestamp asc.
+	// Submissions will include User field.
+	FetchAllScored(ctx context.Context, taskID uuid.UUID) ([]Submission, error)
+	// ApprovedExists reports whether any submission of the user has been approved.
+	ApprovedExists(ctx context.Context, userID uuid.UUID) (bool, error)
 }
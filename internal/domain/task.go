@@ -18,11 +18,41 @@ const (
 	StageFixing    TaskStage = "FIXING"
 )
 
+type ApprovalRule string
+
+const (
+	// RuleManual leaves every submission to admins.
+	RuleManual ApprovalRule = "MANUAL"
+	// RuleAll approves every submission.
+	RuleAll ApprovalRule = "ALL"
+	// RuleApprovedBefore approves users who had a submission approved before.
+	RuleApprovedBefore ApprovalRule = "APPROVED_BEFORE"
+	// RuleAllowedOrgs approves repositories owned by allowed github organizations.
+	RuleAllowedOrgs ApprovalRule = "ALLOWED_ORGS"
+)
+
+// ApprovalPolicy decides which submissions are approved without admins.
+type ApprovalPolicy struct {
+	Rule ApprovalRule
+	// AllowedOrgs is only used by RuleAllowedOrgs.
+	AllowedOrgs []string
+}
+
+// SubmissionLimit overrides how many submissions a user can make on the task.
+// Nil falls back to global limits. Zero means unlimited.
+type SubmissionLimit struct {
+	Hourly *uint64
+	Daily  *uint64
+}
+
 type Task struct {
 	ID          uuid.UUID
 	Title       string
 	Description string
 	Stage       TaskStage
+
+	ApprovalPolicy  ApprovalPolicy
+	SubmissionLimit SubmissionLimit
 }
 
 type TaskUsecase interface {
@@ -31,6 +61,15 @@ type TaskUsecase interface {
 	CreateTask(ctx context.Context, in dto.TaskInput) (out *dto.IDOutput, err error)
 	UpdateTask(ctx context.Context, in dto.UpdateTaskInput) (err error)
 	ChangeStage(ctx context.Context, in dto.TaskStageInput) (err error)
+	// GetReadiness reports whether the task can be available, without changing it.
+	GetReadiness(ctx context.Context, in dto.IDInput) (out *dto.ReadinessOutput, err error)
+	Export(ctx context.Context, in dto.IDInput) (out *dto.TaskBundle, err error)
+	// Import creates a draft task from the bundle.
+	Import(ctx context.Context, in dto.TaskBundle) (out *dto.IDOutput, err error)
+	// Clone copies the task with its sections and resources into a new draft task.
+	Clone(ctx context.Context, in dto.CloneTaskInput) (out *dto.IDOutput, err error)
+	SetApprovalPolicy(ctx context.Context, in dto.ApprovalPolicyInput) (err error)
+	SetSubmissionLimit(ctx context.Context, in dto.SubmissionLimitInput) (err error)
 }
 
 var (
@@ -34,6 +34,11 @@ type User struct {
 	Email      string
 	ProfileURL string
 	Role       UserRole
+	// LimitExempt is true if the user is not limited on submissions.
+	LimitExempt bool
+	// InstallationID is id of github app installation of the user.
+	// It is nil if the user has not installed it.
+	InstallationID *int64
 }
 
 func (u User) IsAdmin() bool {
@@ -46,6 +51,9 @@ type AuthUsecase interface {
 
 type UserUsecase interface {
 	GetSelfInfo(ctx context.Context) (out *dto.UserInfo, err error)
+	SetLimitExemption(ctx context.Context, in dto.LimitExemptionInput) (err error)
+	// SetInstallation links github app installation to the user.
+	SetInstallation(ctx context.Context, in dto.InstallationInput) (err error)
 }
 
 // Defined errors for UserRepository.
@@ -58,4 +66,5 @@ type UserRepository interface {
 	FetchByUsername(ctx context.Context, username string) (User, error)
 	FetchByID(ctx context.Context, id uuid.UUID) (User, error)
 	Create(ctx context.Context, user User) error
+	Update(ctx context.Context, user User) error
 }
@@ -10,10 +10,19 @@ type Config struct {
 	RedisConfig  RedisConfig
 	MYSQLConfig  MYSQLConfig
 	EmailConfig  EmailConfig
+
+	EventBusConfig EventBusConfig
+	BuilderConfig  BuilderConfig
+	RunnerConfig   RunnerConfig
+
+	SubmissionLimitConfig SubmissionLimitConfig
+	RejudgeConfig         RejudgeConfig
 }
 
 type ServerConfig struct {
 	Port int
+	// PublicURL is where users access the service, e.g. "https://r2d2.example.com".
+	PublicURL string
 }
 
 type JWTConfig struct {
@@ -23,6 +32,13 @@ type JWTConfig struct {
 type GitHubConfig struct {
 	ClientID     string
 	ClientSecret string
+
+	// App is not used if AppID is zero.
+	AppID             int64
+	AppPrivateKeyPath string
+
+	// WebhookSecret verifies webhook deliveries. Every delivery is rejected if it is empty.
+	WebhookSecret string
 }
 
 type AWSConfig struct {
@@ -38,13 +54,107 @@ type SQSConfig struct {
 	SubmissionEventQueueURL string
 	BuildEventQueueURL      string
 	TestEventQueueURL       string
+	PushEventQueueURL       string
+	DeadLetterQueueURL      string
 
 	PollInterval time.Duration
+
+	WaitTimeSeconds     int32
+	MaxNumberOfMessages int32
+	VisibilityTimeout   int32
+	Workers             int
+
+	MaxReceiveCount int
+	BaseBackoff     time.Duration
+	MaxBackoff      time.Duration
+}
+
+type EventBusKind string
+
+const (
+	EventBusSQS    EventBusKind = "sqs"
+	EventBusMemory EventBusKind = "memory"
+	EventBusRedis  EventBusKind = "redis"
+)
+
+type EventBusConfig struct {
+	Kind EventBusKind
+
+	// Workers and BufferSize are only used by in-memory event bus.
+	Workers    int
+	BufferSize int
+
+	OutboxRelayInterval time.Duration
+	OutboxBatchSize     int
+
+	// LedgerProcessingTTL is how long events being processed are claimed.
+	LedgerProcessingTTL time.Duration
+	// LedgerTTL is how long processed events are remembered.
+	LedgerTTL time.Duration
+}
+
+type BuilderKind string
+
+const (
+	BuilderLambda BuilderKind = "lambda"
+	BuilderDocker BuilderKind = "docker"
+)
+
+type BuilderConfig struct {
+	Kind BuilderKind
+
+	// Fields below are only used by docker builder.
+	WorkDir     string
+	ImagePrefix string
+	Timeout     time.Duration
+}
+
+type RunnerKind string
+
+const (
+	RunnerSQS    RunnerKind = "sqs"
+	RunnerDocker RunnerKind = "docker"
+)
+
+type RunnerConfig struct {
+	Kind RunnerKind
+
+	// Fields below are only used by docker runner.
+	Workers         int
+	StartTimeout    time.Duration
+	RequestTimeout  time.Duration
+	LoadDuration    time.Duration
+	MaxFailureRatio float64
+}
+
+// SubmissionLimitConfig limits submissions of each user. Zero means unlimited.
+type SubmissionLimitConfig struct {
+	TaskHourly uint64
+	TaskDaily  uint64
+	Hourly     uint64
+	Daily      uint64
+}
+
+type RejudgeConfig struct {
+	// Re-runs are dispatched by BatchSize on every Interval.
+	Interval  time.Duration
+	BatchSize int
 }
 
 type RedisConfig struct {
 	Addr  string
 	DBNum int
+
+	StreamConfig RedisStreamConfig
+}
+
+type RedisStreamConfig struct {
+	Group    string
+	Consumer string
+
+	BatchSize    int64
+	BlockTimeout time.Duration
+	ClaimMinIdle time.Duration
 }
 
 type MYSQLConfig struct {
@@ -68,3 +178,10 @@ func GetAWSConfig() AWSConfig       { return loaded.AWSConfig }
 func GetRedisConfig() RedisConfig   { return loaded.RedisConfig }
 func GetMYSQLConfig() MYSQLConfig   { return loaded.MYSQLConfig }
 func GetEmailConfig() EmailConfig   { return loaded.EmailConfig }
+
+func GetEventBusConfig() EventBusConfig { return loaded.EventBusConfig }
+func GetBuilderConfig() BuilderConfig   { return loaded.BuilderConfig }
+func GetRunnerConfig() RunnerConfig     { return loaded.RunnerConfig }
+
+func GetSubmissionLimitConfig() SubmissionLimitConfig { return loaded.SubmissionLimitConfig }
+func GetRejudgeConfig() RejudgeConfig                 { return loaded.RejudgeConfig }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -17,6 +18,8 @@ func (el *EnvLoader) Fill(ctx context.Context, conf *Config) error {
 	confFuncs := []func(conf *Config) error{
 		el.serverConfig, el.jwtConfig, el.gitHubConfig,
 		el.awsConfig, el.redisConfig, el.emailConfig, el.mysqlConfig,
+		el.eventBusConfig, el.builderConfig, el.runnerConfig,
+		el.submissionLimitConfig, el.rejudgeConfig,
 	}
 
 	for _, f := range confFuncs {
@@ -37,6 +40,7 @@ func (el *EnvLoader) serverConfig(conf *Config) error {
 	}
 
 	serverConf.Port = int(port)
+	serverConf.PublicURL = strings.TrimSuffix(os.Getenv("SERVER_PUBLIC_URL"), "/")
 
 	conf.ServerConfig = serverConf
 	return nil
@@ -57,6 +61,18 @@ func (el *EnvLoader) gitHubConfig(conf *Config) error {
 	githubConf.ClientID = os.Getenv("GITHUB_CLIENT_ID")
 	githubConf.ClientSecret = os.Getenv("GITHUB_CLIENT_SECRET")
 
+	if appIDRaw := os.Getenv("GITHUB_APP_ID"); appIDRaw != "" {
+		appID, err := strconv.ParseInt(appIDRaw, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "parsing github app id")
+		}
+
+		githubConf.AppID = appID
+	}
+
+	githubConf.AppPrivateKeyPath = os.Getenv("GITHUB_APP_PRIVATE_KEY_PATH")
+	githubConf.WebhookSecret = os.Getenv("GITHUB_WEBHOOK_SECRET")
+
 	conf.GitHubConfig = githubConf
 	return nil
 }
@@ -72,6 +88,16 @@ func (el *EnvLoader) awsConfig(conf *Config) error {
 		SubmissionEventQueueURL: os.Getenv("AWS_SQS_SUBMISSION_EVENT_QUEUE_URL"),
 		BuildEventQueueURL:      os.Getenv("AWS_SQS_BUILD_EVENT_QUEUE_URL"),
 		TestEventQueueURL:       os.Getenv("AWS_SQS_TEST_EVENT_QUEUE_URL"),
+		PushEventQueueURL:       os.Getenv("AWS_SQS_PUSH_EVENT_QUEUE_URL"),
+		DeadLetterQueueURL:      os.Getenv("AWS_SQS_DEAD_LETTER_QUEUE_URL"),
+
+		WaitTimeSeconds:     20,
+		MaxNumberOfMessages: 10,
+		Workers:             4,
+
+		MaxReceiveCount: 5,
+		BaseBackoff:     10 * time.Second,
+		MaxBackoff:      15 * time.Minute,
 	}
 
 	pollIntervalRaw := os.Getenv("AWS_SQS_POLL_INTERVAL_SECOND")
@@ -83,6 +109,69 @@ func (el *EnvLoader) awsConfig(conf *Config) error {
 
 	awsConf.SQSConfig.PollInterval = time.Duration(pollInterval) * time.Second
 
+	if waitTimeRaw := os.Getenv("AWS_SQS_WAIT_TIME_SECOND"); waitTimeRaw != "" {
+		waitTime, err := strconv.ParseInt(waitTimeRaw, 10, 32)
+		if err != nil {
+			return errors.Wrap(err, "parsing wait time")
+		}
+
+		awsConf.SQSConfig.WaitTimeSeconds = int32(waitTime)
+	}
+
+	if maxMessagesRaw := os.Getenv("AWS_SQS_MAX_MESSAGES"); maxMessagesRaw != "" {
+		maxMessages, err := strconv.ParseInt(maxMessagesRaw, 10, 32)
+		if err != nil {
+			return errors.Wrap(err, "parsing max number of messages")
+		}
+
+		awsConf.SQSConfig.MaxNumberOfMessages = int32(maxMessages)
+	}
+
+	if visibilityTimeoutRaw := os.Getenv("AWS_SQS_VISIBILITY_TIMEOUT_SECOND"); visibilityTimeoutRaw != "" {
+		visibilityTimeout, err := strconv.ParseInt(visibilityTimeoutRaw, 10, 32)
+		if err != nil {
+			return errors.Wrap(err, "parsing visibility timeout")
+		}
+
+		awsConf.SQSConfig.VisibilityTimeout = int32(visibilityTimeout)
+	}
+
+	if workersRaw := os.Getenv("AWS_SQS_WORKERS"); workersRaw != "" {
+		workers, err := strconv.ParseInt(workersRaw, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "parsing workers")
+		}
+
+		awsConf.SQSConfig.Workers = int(workers)
+	}
+
+	if maxReceiveCountRaw := os.Getenv("AWS_SQS_MAX_RECEIVE_COUNT"); maxReceiveCountRaw != "" {
+		maxReceiveCount, err := strconv.ParseInt(maxReceiveCountRaw, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "parsing max receive count")
+		}
+
+		awsConf.SQSConfig.MaxReceiveCount = int(maxReceiveCount)
+	}
+
+	if baseBackoffRaw := os.Getenv("AWS_SQS_BASE_BACKOFF_SECOND"); baseBackoffRaw != "" {
+		baseBackoff, err := strconv.ParseInt(baseBackoffRaw, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "parsing base backoff")
+		}
+
+		awsConf.SQSConfig.BaseBackoff = time.Duration(baseBackoff) * time.Second
+	}
+
+	if maxBackoffRaw := os.Getenv("AWS_SQS_MAX_BACKOFF_SECOND"); maxBackoffRaw != "" {
+		maxBackoff, err := strconv.ParseInt(maxBackoffRaw, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "parsing max backoff")
+		}
+
+		awsConf.SQSConfig.MaxBackoff = time.Duration(maxBackoff) * time.Second
+	}
+
 	conf.AWSConfig = awsConf
 	return nil
 }
@@ -101,10 +190,69 @@ func (el *EnvLoader) redisConfig(conf *Config) error {
 
 	redisConf.DBNum = int(redisDBNum)
 
+	streamConf, err := el.redisStreamConfig()
+	if err != nil {
+		return errors.Wrap(err, "parsing redis stream config")
+	}
+
+	redisConf.StreamConfig = streamConf
+
 	conf.RedisConfig = redisConf
 	return nil
 }
 
+func (el *EnvLoader) redisStreamConfig() (RedisStreamConfig, error) {
+	streamConf := RedisStreamConfig{
+		Group:        "r2d2-api-server",
+		BatchSize:    10,
+		BlockTimeout: 5 * time.Second,
+		ClaimMinIdle: time.Minute,
+	}
+
+	if group := os.Getenv("REDIS_STREAM_GROUP"); group != "" {
+		streamConf.Group = group
+	}
+
+	streamConf.Consumer = os.Getenv("REDIS_STREAM_CONSUMER")
+	if streamConf.Consumer == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return RedisStreamConfig{}, errors.Wrap(err, "getting hostname for consumer name")
+		}
+
+		streamConf.Consumer = hostname
+	}
+
+	if batchSizeRaw := os.Getenv("REDIS_STREAM_BATCH_SIZE"); batchSizeRaw != "" {
+		batchSize, err := strconv.ParseInt(batchSizeRaw, 10, 64)
+		if err != nil {
+			return RedisStreamConfig{}, errors.Wrap(err, "parsing batch size")
+		}
+
+		streamConf.BatchSize = batchSize
+	}
+
+	if blockTimeoutRaw := os.Getenv("REDIS_STREAM_BLOCK_SECOND"); blockTimeoutRaw != "" {
+		blockTimeout, err := strconv.ParseInt(blockTimeoutRaw, 10, 64)
+		if err != nil {
+			return RedisStreamConfig{}, errors.Wrap(err, "parsing block timeout")
+		}
+
+		streamConf.BlockTimeout = time.Duration(blockTimeout) * time.Second
+	}
+
+	if claimMinIdleRaw := os.Getenv("REDIS_STREAM_CLAIM_MIN_IDLE_SECOND"); claimMinIdleRaw != "" {
+		claimMinIdle, err := strconv.ParseInt(claimMinIdleRaw, 10, 64)
+		if err != nil {
+			return RedisStreamConfig{}, errors.Wrap(err, "parsing claim min idle")
+		}
+
+		streamConf.ClaimMinIdle = time.Duration(claimMinIdle) * time.Second
+	}
+
+	return streamConf, nil
+}
+
 func (el *EnvLoader) mysqlConfig(conf *Config) error {
 	mysqlConf := MYSQLConfig{}
 
@@ -135,3 +283,255 @@ func (el *EnvLoader) emailConfig(conf *Config) error {
 	conf.EmailConfig = emailConf
 	return nil
 }
+
+func (el *EnvLoader) eventBusConfig(conf *Config) error {
+	eventBusConf := EventBusConfig{
+		Kind:       EventBusSQS,
+		Workers:    1,
+		BufferSize: 100,
+
+		OutboxRelayInterval: time.Second,
+		OutboxBatchSize:     100,
+
+		LedgerProcessingTTL: 5 * time.Minute,
+		LedgerTTL:           7 * 24 * time.Hour,
+	}
+
+	if kind := os.Getenv("EVENT_BUS_KIND"); kind != "" {
+		eventBusConf.Kind = EventBusKind(kind)
+	}
+
+	switch eventBusConf.Kind {
+	case EventBusSQS, EventBusMemory, EventBusRedis:
+	default:
+		return errors.Errorf("unknown event bus kind: %s", eventBusConf.Kind)
+	}
+
+	if workersRaw := os.Getenv("EVENT_BUS_WORKERS"); workersRaw != "" {
+		workers, err := strconv.ParseInt(workersRaw, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "parsing event bus workers")
+		}
+
+		eventBusConf.Workers = int(workers)
+	}
+
+	if bufferSizeRaw := os.Getenv("EVENT_BUS_BUFFER_SIZE"); bufferSizeRaw != "" {
+		bufferSize, err := strconv.ParseInt(bufferSizeRaw, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "parsing event bus buffer size")
+		}
+
+		eventBusConf.BufferSize = int(bufferSize)
+	}
+
+	if relayIntervalRaw := os.Getenv("OUTBOX_RELAY_INTERVAL_SECOND"); relayIntervalRaw != "" {
+		relayInterval, err := strconv.ParseInt(relayIntervalRaw, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "parsing outbox relay interval")
+		}
+
+		eventBusConf.OutboxRelayInterval = time.Duration(relayInterval) * time.Second
+	}
+
+	if batchSizeRaw := os.Getenv("OUTBOX_BATCH_SIZE"); batchSizeRaw != "" {
+		batchSize, err := strconv.ParseInt(batchSizeRaw, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "parsing outbox batch size")
+		}
+
+		eventBusConf.OutboxBatchSize = int(batchSize)
+	}
+
+	if processingTTLRaw := os.Getenv("EVENT_LEDGER_PROCESSING_TTL_SECOND"); processingTTLRaw != "" {
+		processingTTL, err := strconv.ParseInt(processingTTLRaw, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "parsing event ledger processing ttl")
+		}
+
+		eventBusConf.LedgerProcessingTTL = time.Duration(processingTTL) * time.Second
+	}
+
+	if ledgerTTLRaw := os.Getenv("EVENT_LEDGER_TTL_HOUR"); ledgerTTLRaw != "" {
+		ledgerTTL, err := strconv.ParseInt(ledgerTTLRaw, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "parsing event ledger ttl")
+		}
+
+		eventBusConf.LedgerTTL = time.Duration(ledgerTTL) * time.Hour
+	}
+
+	conf.EventBusConfig = eventBusConf
+	return nil
+}
+
+func (el *EnvLoader) builderConfig(conf *Config) error {
+	builderConf := BuilderConfig{
+		Kind:        BuilderLambda,
+		WorkDir:     os.TempDir(),
+		ImagePrefix: "r2d2",
+		Timeout:     10 * time.Minute,
+	}
+
+	if kind := os.Getenv("BUILDER_KIND"); kind != "" {
+		builderConf.Kind = BuilderKind(kind)
+	}
+
+	switch builderConf.Kind {
+	case BuilderLambda, BuilderDocker:
+	default:
+		return errors.Errorf("unknown builder kind: %s", builderConf.Kind)
+	}
+
+	if workDir := os.Getenv("BUILDER_WORK_DIR"); workDir != "" {
+		builderConf.WorkDir = workDir
+	}
+
+	if imagePrefix := os.Getenv("BUILDER_IMAGE_PREFIX"); imagePrefix != "" {
+		builderConf.ImagePrefix = imagePrefix
+	}
+
+	if timeoutRaw := os.Getenv("BUILDER_TIMEOUT_SECOND"); timeoutRaw != "" {
+		timeout, err := strconv.ParseInt(timeoutRaw, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "parsing builder timeout")
+		}
+
+		builderConf.Timeout = time.Duration(timeout) * time.Second
+	}
+
+	conf.BuilderConfig = builderConf
+	return nil
+}
+
+func (el *EnvLoader) runnerConfig(conf *Config) error {
+	runnerConf := RunnerConfig{
+		Kind:            RunnerSQS,
+		Workers:         1,
+		StartTimeout:    time.Minute,
+		RequestTimeout:  5 * time.Second,
+		LoadDuration:    time.Minute,
+		MaxFailureRatio: 0.01,
+	}
+
+	if kind := os.Getenv("RUNNER_KIND"); kind != "" {
+		runnerConf.Kind = RunnerKind(kind)
+	}
+
+	switch runnerConf.Kind {
+	case RunnerSQS, RunnerDocker:
+	default:
+		return errors.Errorf("unknown runner kind: %s", runnerConf.Kind)
+	}
+
+	if workersRaw := os.Getenv("RUNNER_WORKERS"); workersRaw != "" {
+		workers, err := strconv.ParseInt(workersRaw, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "parsing runner workers")
+		}
+
+		runnerConf.Workers = int(workers)
+	}
+
+	durations := []struct {
+		key string
+		dst *time.Duration
+	}{
+		{"RUNNER_START_TIMEOUT_SECOND", &runnerConf.StartTimeout},
+		{"RUNNER_REQUEST_TIMEOUT_SECOND", &runnerConf.RequestTimeout},
+		{"RUNNER_LOAD_DURATION_SECOND", &runnerConf.LoadDuration},
+	}
+
+	for _, d := range durations {
+		raw := os.Getenv(d.key)
+		if raw == "" {
+			continue
+		}
+
+		seconds, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "parsing %s", d.key)
+		}
+
+		*d.dst = time.Duration(seconds) * time.Second
+	}
+
+	if ratioRaw := os.Getenv("RUNNER_MAX_FAILURE_RATIO"); ratioRaw != "" {
+		ratio, err := strconv.ParseFloat(ratioRaw, 64)
+		if err != nil {
+			return errors.Wrap(err, "parsing max failure ratio")
+		}
+
+		runnerConf.MaxFailureRatio = ratio
+	}
+
+	conf.RunnerConfig = runnerConf
+	return nil
+}
+
+func (el *EnvLoader) submissionLimitConfig(conf *Config) error {
+	limitConf := SubmissionLimitConfig{}
+
+	limits := []struct {
+		key string
+		dst *uint64
+	}{
+		{"SUBMISSION_LIMIT_TASK_HOURLY", &limitConf.TaskHourly},
+		{"SUBMISSION_LIMIT_TASK_DAILY", &limitConf.TaskDaily},
+		{"SUBMISSION_LIMIT_HOURLY", &limitConf.Hourly},
+		{"SUBMISSION_LIMIT_DAILY", &limitConf.Daily},
+	}
+
+	for _, l := range limits {
+		raw := os.Getenv(l.key)
+		if raw == "" {
+			continue
+		}
+
+		count, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "parsing %s", l.key)
+		}
+
+		*l.dst = count
+	}
+
+	conf.SubmissionLimitConfig = limitConf
+	return nil
+}
+
+func (el *EnvLoader) rejudgeConfig(conf *Config) error {
+	rejudgeConf := RejudgeConfig{
+		Interval:  10 * time.Second,
+		BatchSize: 5,
+	}
+
+	if intervalRaw := os.Getenv("REJUDGE_INTERVAL_SECOND"); intervalRaw != "" {
+		interval, err := strconv.ParseInt(intervalRaw, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "parsing rejudge interval")
+		}
+
+		rejudgeConf.Interval = time.Duration(interval) * time.Second
+	}
+
+	if batchSizeRaw := os.Getenv("REJUDGE_BATCH_SIZE"); batchSizeRaw != "" {
+		batchSize, err := strconv.ParseInt(batchSizeRaw, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "parsing rejudge batch size")
+		}
+
+		rejudgeConf.BatchSize = int(batchSize)
+	}
+
+	if rejudgeConf.Interval <= 0 {
+		return errors.Errorf("rejudge interval should be positive: %s", rejudgeConf.Interval)
+	}
+
+	if rejudgeConf.BatchSize <= 0 {
+		return errors.Errorf("rejudge batch size should be positive: %d", rejudgeConf.BatchSize)
+	}
+
+	conf.RejudgeConfig = rejudgeConf
+	return nil
+}
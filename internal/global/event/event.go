@@ -13,6 +13,7 @@ const (
 	TopicSubmission Topic = "submission"
 	TopicBuild      Topic = "build"
 	TopicTest       Topic = "test"
+	TopicPush       Topic = "push"
 )
 
 // Event schema for TopicSubmission
@@ -25,10 +26,36 @@ type SubmissionEvent struct {
 	Timestamp    time.Time        `json:"timestamp"`
 }
 
+// Event schema for TopicPush
+type PushEvent struct {
+	ID         uuid.UUID `json:"id"`
+	Repository string    `json:"repository"`
+	Branch     string    `json:"branch"`
+	CommitHash string    `json:"commitHash"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
 // Event schema for TopicBuild, TopicTest
 type ExecEvent struct {
 	ID      uuid.UUID     `json:"id"`
 	Success bool          `json:"success"`
 	Took    time.Duration `json:"took"`
 	Extra   string        `json:"extra"`
+
+	// Results holds result of each section ran. Only used by TopicTest.
+	Results []SectionResult `json:"results,omitempty"`
+}
+
+// Result schema of a section, carried by ExecEvent.
+type SectionResult struct {
+	SectionID uuid.UUID `json:"sectionID"`
+	Passed    bool      `json:"passed"`
+	Error     string    `json:"error"`
+
+	TotalRequests  int `json:"totalRequests"`
+	FailedRequests int `json:"failedRequests"`
+
+	P50 time.Duration `json:"p50"`
+	P95 time.Duration `json:"p95"`
+	P99 time.Duration `json:"p99"`
 }
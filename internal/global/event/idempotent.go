@@ -0,0 +1,97 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"reflect"
+	"runtime"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=idempotent.go -destination=../../../test/mocks/idempotent.go -package=mocks
+
+// ErrEventInProgress is returned by Ledger when another delivery of the event is being processed.
+var ErrEventInProgress = errors.New("event is being processed")
+
+// Ledger records events processed by each handler.
+type Ledger interface {
+	// Claim marks (handler, eventID) pair as being processed for a while.
+	// It reports false if the pair is already completed,
+	// and returns ErrEventInProgress if the pair is claimed by another delivery.
+	Claim(ctx context.Context, handler string, eventID uuid.UUID) (bool, error)
+	// Complete records the pair as processed.
+	Complete(ctx context.Context, handler string, eventID uuid.UUID) error
+	// Release removes the claim so the event can be processed again.
+	Release(ctx context.Context, handler string, eventID uuid.UUID) error
+}
+
+// Idempotent wraps f so that it processes each event at most once successfully.
+// Event is identified by its field "id". Redelivered events are skipped.
+// If f fails, the claim is released so that redelivery can retry it.
+// If the process dies while f is running, the claim expires and redelivery can retry it.
+func Idempotent(ledger Ledger, name string, f HandlerFunc) HandlerFunc {
+	return func(ctx context.Context, topic Topic, payload []byte) error {
+		var identity struct {
+			ID uuid.UUID `json:"id"`
+		}
+		if err := json.Unmarshal(payload, &identity); err != nil {
+			// Let the handler decide what to do with malformed payload.
+			return f(ctx, topic, payload)
+		}
+
+		if identity.ID == uuid.Nil {
+			// Events without id can't be told apart.
+			return f(ctx, topic, payload)
+		}
+
+		claimed, err := ledger.Claim(ctx, name, identity.ID)
+		if err != nil {
+			// Returning error on ErrEventInProgress, so it is redelivered after the claim expires.
+			return errors.Wrap(err, "claiming event")
+		}
+
+		if !claimed {
+			return NoErrSkipHandler
+		}
+
+		err = f(ctx, topic, payload)
+		if err != nil && err != NoErrSkipHandler {
+			if releaseErr := ledger.Release(ctx, name, identity.ID); releaseErr != nil {
+				return stderrors.Join(err, releaseErr)
+			}
+			return err
+		}
+
+		if completeErr := ledger.Complete(ctx, name, identity.ID); completeErr != nil {
+			return errors.Wrap(completeErr, "completing event")
+		}
+
+		return err
+	}
+}
+
+// IdempotentSubscriber wraps every handler subscribed with Idempotent.
+// Handlers are named after its function and topic.
+type IdempotentSubscriber struct {
+	Subscriber
+	Ledger Ledger
+}
+
+var _ Subscriber = (*IdempotentSubscriber)(nil)
+
+func (s *IdempotentSubscriber) Subscribe(ctx context.Context, topic Topic, handlers ...HandlerFunc) error {
+	wrapped := make([]HandlerFunc, len(handlers))
+	for idx, f := range handlers {
+		name := string(topic) + ":" + handlerName(f)
+		wrapped[idx] = Idempotent(s.Ledger, name, f)
+	}
+
+	return s.Subscriber.Subscribe(ctx, topic, wrapped...)
+}
+
+func handlerName(f HandlerFunc) string {
+	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+}
@@ -0,0 +1,140 @@
+package event_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	"github.com/oneee-playground/r2d2-api-server/test/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestIdempotentSuite(t *testing.T) {
+	suite.Run(t, new(IdempotentSuite))
+}
+
+type IdempotentSuite struct {
+	suite.Suite
+
+	ctl  *gomock.Controller
+	mock struct {
+		ledger *mocks.MockLedger
+	}
+}
+
+func (s *IdempotentSuite) SetupTest() {
+	s.ctl = gomock.NewController(s.T())
+	s.mock.ledger = mocks.NewMockLedger(s.ctl)
+}
+
+func (s *IdempotentSuite) TestIdempotent() {
+	testEvent := event.SubmissionEvent{ID: uuid.New()}
+	testPayload, _ := json.Marshal(testEvent)
+
+	handlerErr := errors.New("handler failed")
+
+	testcases := []struct {
+		desc       string
+		setup      func()
+		handlerErr error
+		wantCalled bool
+		wantErr    error
+	}{
+		{
+			desc: "first delivery",
+			setup: func() {
+				s.mock.ledger.EXPECT().
+					Claim(gomock.Any(), "handler", testEvent.ID).Return(true, nil)
+				s.mock.ledger.EXPECT().
+					Complete(gomock.Any(), "handler", testEvent.ID).Return(nil)
+			},
+			wantCalled: true,
+			wantErr:    nil,
+		},
+		{
+			desc: "redelivery",
+			setup: func() {
+				s.mock.ledger.EXPECT().
+					Claim(gomock.Any(), "handler", testEvent.ID).Return(false, nil)
+			},
+			wantCalled: false,
+			wantErr:    event.NoErrSkipHandler,
+		},
+		{
+			desc: "another delivery in progress",
+			setup: func() {
+				s.mock.ledger.EXPECT().
+					Claim(gomock.Any(), "handler", testEvent.ID).Return(false, event.ErrEventInProgress)
+			},
+			wantCalled: false,
+			wantErr:    event.ErrEventInProgress,
+		},
+		{
+			desc: "handler failure releases claim",
+			setup: func() {
+				s.mock.ledger.EXPECT().
+					Claim(gomock.Any(), "handler", testEvent.ID).Return(true, nil)
+				s.mock.ledger.EXPECT().
+					Release(gomock.Any(), "handler", testEvent.ID).Return(nil)
+			},
+			handlerErr: handlerErr,
+			wantCalled: true,
+			wantErr:    handlerErr,
+		},
+		{
+			desc: "skip completes claim",
+			setup: func() {
+				s.mock.ledger.EXPECT().
+					Claim(gomock.Any(), "handler", testEvent.ID).Return(true, nil)
+				s.mock.ledger.EXPECT().
+					Complete(gomock.Any(), "handler", testEvent.ID).Return(nil)
+			},
+			handlerErr: event.NoErrSkipHandler,
+			wantCalled: true,
+			wantErr:    event.NoErrSkipHandler,
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			tc.setup()
+
+			called := false
+			f := event.Idempotent(s.mock.ledger, "handler",
+				func(ctx context.Context, topic event.Topic, payload []byte) error {
+					called = true
+					return tc.handlerErr
+				},
+			)
+
+			err := f(context.Background(), event.TopicSubmission, testPayload)
+			s.ErrorIs(err, tc.wantErr)
+			s.Equal(tc.wantCalled, called)
+		})
+	}
+}
+
+func (s *IdempotentSuite) TestIdempotentWithoutID() {
+	payload, _ := json.Marshal(struct {
+		Kind string `json:"kind"`
+	}{Kind: "test"})
+
+	// Ledger should not be used.
+	called := 0
+	f := event.Idempotent(s.mock.ledger, "handler",
+		func(ctx context.Context, topic event.Topic, payload []byte) error {
+			called++
+			return nil
+		},
+	)
+
+	for range 2 {
+		err := f(context.Background(), event.TopicSubmission, payload)
+		s.NoError(err)
+	}
+	s.Equal(2, called)
+}
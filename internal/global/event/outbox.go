@@ -0,0 +1,23 @@
+package event
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=outbox.go -destination=../../../test/mocks/outbox.go -package=mocks
+
+// OutboxMessage is an event stored in outbox, waiting to be relayed.
+type OutboxMessage struct {
+	ID      uuid.UUID
+	Topic   Topic
+	Payload []byte
+}
+
+type OutboxStorage interface {
+	// FetchUndelivered fetches messages not delivered yet.
+	// It is ordered by the time messages were stored.
+	FetchUndelivered(ctx context.Context, limit int) ([]OutboxMessage, error)
+	MarkDelivered(ctx context.Context, id uuid.UUID) error
+}
@@ -8,6 +8,11 @@ import (
 type Error struct {
 	StatusCode int
 	Message    string
+	// Details is optional structured information about the error.
+	// It is rendered as-is if not nil.
+	Details any
+	// Headers are set on the response if not nil.
+	Headers map[string]string
 }
 
 func NewErr(status int, msg string) Error {
@@ -17,6 +22,26 @@ func NewErr(status int, msg string) Error {
 	}
 }
 
+func NewErrWithDetails(status int, msg string, details any) Error {
+	return Error{
+		StatusCode: status,
+		Message:    msg,
+		Details:    details,
+	}
+}
+
+// WithHeader returns copy of the error which sets the header on the response.
+func (e Error) WithHeader(key, value string) Error {
+	headers := make(map[string]string, len(e.Headers)+1)
+	for k, v := range e.Headers {
+		headers[k] = v
+	}
+	headers[key] = value
+
+	e.Headers = headers
+	return e
+}
+
 func (e Error) Error() string {
 	return strconv.Itoa(e.StatusCode) + ": " + e.Message
 }
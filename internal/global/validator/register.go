@@ -20,6 +20,13 @@ func RegisterTaskStage(v *validator.Validate) error {
 		return err
 	}
 
+	err = v.RegisterValidation("approval_rule", func(fl validator.FieldLevel) bool {
+		return ApprovalRuleValid(domain.ApprovalRule(fl.Field().String()))
+	})
+	if err != nil {
+		return err
+	}
+
 	err = v.RegisterValidation("section_type", func(fl validator.FieldLevel) bool {
 		return SectionTypeValid(domain.SectionType(fl.Field().String()))
 	})
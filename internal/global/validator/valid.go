@@ -18,6 +18,14 @@ func SubmissionAcitonValid(a domain.SubmissionAction) bool {
 	return false
 }
 
+func ApprovalRuleValid(r domain.ApprovalRule) bool {
+	switch r {
+	case domain.RuleManual, domain.RuleAll, domain.RuleApprovedBefore, domain.RuleAllowedOrgs:
+		return true
+	}
+	return false
+}
+
 func SectionTypeValid(t domain.SectionType) bool {
 	switch t {
 	case domain.TypeScenario, domain.TypeLoad:
@@ -3,6 +3,7 @@ package sqs
 import (
 	"context"
 	"encoding/json"
+	"strconv"
 	"sync"
 	"time"
 
@@ -15,10 +16,33 @@ import (
 )
 
 type QueueConfig struct {
-	URL          string
+	URL string
+	// PollInterval is the interval between receives when long polling is disabled,
+	// or after receiving has failed.
 	PollInterval time.Duration
+
+	// WaitTimeSeconds enables long polling if it is non-zero.
+	WaitTimeSeconds     int32
+	MaxNumberOfMessages int32
+	// VisibilityTimeout overrides queue's visibility timeout if it is non-zero.
+	VisibilityTimeout int32
+	// Workers is the number of messages handled concurrently.
+	// Messages of the same submission are always handled in order.
+	Workers int
+
+	// DeadLetterURL is the queue messages are moved into after MaxReceiveCount attempts.
+	// Messages will be just dropped (and logged) if it is empty.
+	DeadLetterURL   string
+	MaxReceiveCount int
+	// BaseBackoff is the visibility timeout after first failure.
+	// It doubles on every failure until it reaches MaxBackoff.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
 }
 
+// maxVisibilityTimeout is the maximum visibility timeout sqs allows.
+const maxVisibilityTimeout = 12 * time.Hour
+
 type SQSEventBus struct {
 	client *sqs.Client
 	logger *zap.Logger
@@ -84,70 +108,228 @@ func (b *SQSEventBus) Listen(ctx context.Context) {
 }
 
 func (b *SQSEventBus) listenTopic(ctx context.Context, wg *sync.WaitGroup, topic event.Topic, queue QueueConfig) {
-	ticker := time.NewTicker(queue.PollInterval)
-
 	input := &sqs.ReceiveMessageInput{
-		QueueUrl: aws.String(queue.URL),
-		// TODO: Are ther any more things to add?
+		QueueUrl:            aws.String(queue.URL),
+		WaitTimeSeconds:     queue.WaitTimeSeconds,
+		MaxNumberOfMessages: queue.MaxNumberOfMessages,
+		VisibilityTimeout:   queue.VisibilityTimeout,
+		MessageSystemAttributeNames: []types.MessageSystemAttributeName{
+			types.MessageSystemAttributeNameApproximateReceiveCount,
+		},
 	}
 
 	defer wg.Done()
-	defer ticker.Stop()
 
 	for {
-		select {
-		case <-ctx.Done():
+		if ctx.Err() != nil {
 			b.logger.Error("context done", zap.Error(ctx.Err()))
 			return
-		case <-ticker.C:
-			output, err := b.client.ReceiveMessage(ctx, input)
-			if err != nil {
-				b.logger.Error("failed to receive message",
-					zap.String("topic", string(topic)),
-					zap.Error(err),
-				)
-				continue
-			}
+		}
+
+		output, err := b.client.ReceiveMessage(ctx, input)
+		if err != nil {
+			b.logger.Error("failed to receive message",
+				zap.String("topic", string(topic)),
+				zap.Error(err),
+			)
+			sleep(ctx, queue.PollInterval)
+			continue
+		}
 
-			if len(output.Messages) == 0 {
-				continue
+		if len(output.Messages) == 0 {
+			if queue.WaitTimeSeconds == 0 {
+				sleep(ctx, queue.PollInterval)
 			}
+			continue
+		}
 
-			entries := make([]types.DeleteMessageBatchRequestEntry, len(output.Messages))
+		entries := b.handleMessages(ctx, topic, queue, output.Messages)
+		if len(entries) == 0 {
+			continue
+		}
+
+		_, err = b.client.DeleteMessageBatch(ctx, &sqs.DeleteMessageBatchInput{
+			QueueUrl: aws.String(queue.URL),
+			Entries:  entries,
+		})
+		if err != nil {
+			b.logger.Error("failed to delete messages",
+				zap.String("topic", string(topic)),
+				zap.Error(err),
+			)
+		}
+	}
+}
 
-			for idx, message := range output.Messages {
-				payload := []byte(*message.Body)
+// handleMessages handles messages concurrently with queue.Workers goroutines.
+// Messages are grouped by its submission, and each group is handled in received order.
+// It returns entries of messages that should be deleted.
+func (b *SQSEventBus) handleMessages(
+	ctx context.Context, topic event.Topic, queue QueueConfig, messages []types.Message,
+) []types.DeleteMessageBatchRequestEntry {
+	var (
+		mu      sync.Mutex
+		entries = make([]types.DeleteMessageBatchRequestEntry, 0, len(messages))
+	)
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, max(queue.Workers, 1))
+
+	for _, group := range groupByOrderingKey(messages) {
+		wg.Add(1)
+		sem <- struct{}{}
 
-				for _, f := range b.handlers[topic] {
-					err := f(ctx, topic, payload)
-					if err == event.NoErrSkipHandler {
-						continue
-					}
+		go func(group []types.Message) {
+			defer wg.Done()
+			defer func() { <-sem }()
 
-					if err != nil {
-						b.logger.Error("failed to handle message",
-							zap.String("topic", string(topic)),
-							zap.Error(err),
-						)
-					}
+			for _, message := range group {
+				if !b.handleMessage(ctx, topic, queue, message) {
+					continue
 				}
 
-				entries[idx] = types.DeleteMessageBatchRequestEntry{
+				mu.Lock()
+				entries = append(entries, types.DeleteMessageBatchRequestEntry{
 					Id:            message.MessageId,
 					ReceiptHandle: message.ReceiptHandle,
-				}
+				})
+				mu.Unlock()
 			}
+		}(group)
+	}
 
-			_, err = b.client.DeleteMessageBatch(ctx, &sqs.DeleteMessageBatchInput{
-				QueueUrl: aws.String(queue.URL),
-				Entries:  entries,
-			})
-			if err != nil {
-				b.logger.Error("failed to delete messages",
-					zap.String("topic", string(topic)),
-					zap.Error(err),
-				)
-			}
+	wg.Wait()
+
+	return entries
+}
+
+// handleMessage runs all handlers of the topic with the message.
+// It reports whether the message should be deleted from the queue.
+func (b *SQSEventBus) handleMessage(ctx context.Context, topic event.Topic, queue QueueConfig, message types.Message) bool {
+	payload := []byte(*message.Body)
+
+	failed := false
+	for _, f := range b.handlers[topic] {
+		err := f(ctx, topic, payload)
+		if err == event.NoErrSkipHandler {
+			continue
+		}
+
+		if err != nil {
+			failed = true
+			b.logger.Error("failed to handle message",
+				zap.String("topic", string(topic)),
+				zap.Error(err),
+			)
 		}
 	}
+
+	if !failed {
+		return true
+	}
+
+	receiveCount, _ := strconv.Atoi(message.Attributes[string(types.MessageSystemAttributeNameApproximateReceiveCount)])
+
+	if receiveCount >= queue.MaxReceiveCount {
+		if err := b.deadLetter(ctx, topic, queue, message); err != nil {
+			b.logger.Error("failed to send message to dead-letter queue",
+				zap.String("topic", string(topic)),
+				zap.String("payload", *message.Body),
+				zap.Error(err),
+			)
+			return false
+		}
+
+		return true
+	}
+
+	// Leave the message on the queue, and make it visible again after backoff.
+	_, err := b.client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          aws.String(queue.URL),
+		ReceiptHandle:     message.ReceiptHandle,
+		VisibilityTimeout: int32(backoff(queue, receiveCount).Seconds()),
+	})
+	if err != nil {
+		b.logger.Error("failed to change message visibility",
+			zap.String("topic", string(topic)),
+			zap.Error(err),
+		)
+	}
+
+	return false
+}
+
+func (b *SQSEventBus) deadLetter(ctx context.Context, topic event.Topic, queue QueueConfig, message types.Message) error {
+	b.logger.Error("message exceeded max receive count",
+		zap.String("topic", string(topic)),
+		zap.String("payload", *message.Body),
+	)
+
+	if queue.DeadLetterURL == "" {
+		return nil
+	}
+
+	input := &sqs.SendMessageInput{
+		QueueUrl:    aws.String(queue.DeadLetterURL),
+		MessageBody: message.Body,
+	}
+
+	if _, err := b.client.SendMessage(ctx, input); err != nil {
+		return errors.Wrap(err, "sending message")
+	}
+
+	return nil
+}
+
+// backoff calculates visibility timeout after receiveCount failures.
+func backoff(queue QueueConfig, receiveCount int) time.Duration {
+	maxBackoff := min(queue.MaxBackoff, maxVisibilityTimeout)
+
+	timeout := queue.BaseBackoff
+	for i := 1; i < receiveCount && timeout < maxBackoff; i++ {
+		timeout *= 2
+	}
+
+	return min(timeout, maxBackoff)
+}
+
+// groupByOrderingKey groups messages by submission they belong to, keeping received order.
+// SubmissionEvent is keyed by its field submissionID, and ExecEvent by its field id.
+func groupByOrderingKey(messages []types.Message) [][]types.Message {
+	var (
+		groups  = make([][]types.Message, 0, len(messages))
+		indexes = make(map[string]int)
+	)
+
+	for _, message := range messages {
+		var keys struct {
+			ID           string `json:"id"`
+			SubmissionID string `json:"submissionID"`
+		}
+		// Malformed payloads fall back to empty key. Handlers will report them.
+		_ = json.Unmarshal([]byte(*message.Body), &keys)
+
+		key := keys.SubmissionID
+		if key == "" {
+			key = keys.ID
+		}
+
+		idx, ok := indexes[key]
+		if !ok || key == "" {
+			idx = len(groups)
+			indexes[key] = idx
+			groups = append(groups, nil)
+		}
+
+		groups[idx] = append(groups[idx], message)
+	}
+
+	return groups
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
 }
@@ -0,0 +1,54 @@
+package sqs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	queue := QueueConfig{
+		BaseBackoff: 10 * time.Second,
+		MaxBackoff:  time.Minute,
+	}
+
+	testcases := []struct {
+		desc         string
+		receiveCount int
+		expected     time.Duration
+	}{
+		{desc: "first failure", receiveCount: 1, expected: 10 * time.Second},
+		{desc: "doubled", receiveCount: 3, expected: 40 * time.Second},
+		{desc: "capped", receiveCount: 10, expected: time.Minute},
+	}
+
+	for _, tc := range testcases {
+		assert.Equal(t, tc.expected, backoff(queue, tc.receiveCount), tc.desc)
+	}
+}
+
+func TestGroupByOrderingKey(t *testing.T) {
+	newMessage := func(body string) types.Message {
+		return types.Message{Body: aws.String(body)}
+	}
+
+	var (
+		submitA = newMessage(`{"id":"1","submissionID":"a"}`)
+		submitB = newMessage(`{"id":"2","submissionID":"b"}`)
+		cancelA = newMessage(`{"id":"3","submissionID":"a"}`)
+		buildA  = newMessage(`{"id":"a","success":true}`)
+		invalid = newMessage(`invalid`)
+	)
+
+	groups := groupByOrderingKey([]types.Message{submitA, submitB, cancelA, buildA, invalid, invalid})
+
+	assert.Equal(t, [][]types.Message{
+		{submitA, cancelA, buildA},
+		{submitB},
+		{invalid},
+		{invalid},
+	}, groups)
+}
@@ -0,0 +1,32 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// Contest holds the schema definition for the Contest entity.
+type Contest struct {
+	ent.Schema
+}
+
+// Fields of the Contest.
+func (Contest) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.New()).Unique(),
+		field.String("title"),
+		field.Time("startAt"),
+		field.Time("endAt"),
+		// Scoreboard never freezes if it is not set.
+		field.Time("freezeAt").Optional().Nillable(),
+	}
+}
+
+// Edges of the Contest.
+func (Contest) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("tasks", ContestTask.Type),
+	}
+}
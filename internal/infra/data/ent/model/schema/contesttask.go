@@ -0,0 +1,42 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// ContestTask holds the schema definition for the ContestTask entity.
+type ContestTask struct {
+	ent.Schema
+}
+
+// Fields of the ContestTask.
+func (ContestTask) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.New()).Unique(),
+		field.Uint8("index"),
+		field.UUID("contestID", uuid.New()),
+		field.UUID("taskID", uuid.New()),
+	}
+}
+
+// Edges of the ContestTask.
+func (ContestTask) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("contest", Contest.Type).Field("contestID").
+			Ref("tasks").Unique().Required(),
+		edge.From("task", Task.Type).Field("taskID").
+			Ref("contests").Unique().Required(),
+	}
+}
+
+// Indexes of the ContestTask.
+func (ContestTask) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("contestID", "taskID").Unique(),
+		index.Fields("contestID", "index").Unique(),
+	}
+}
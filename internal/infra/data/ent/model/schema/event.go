@@ -17,7 +17,7 @@ func (Event) Fields() []ent.Field {
 	return []ent.Field{
 		field.UUID("id", uuid.New()).Unique(),
 		field.String("kind"),
-		field.String("extra"),
+		field.Text("extra"),
 		field.Time("timestamp"),
 		field.UUID("submissionID", uuid.New()),
 	}
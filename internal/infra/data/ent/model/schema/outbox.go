@@ -0,0 +1,32 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Outbox holds the schema definition for the Outbox entity.
+// It stores events published within a transaction until they are relayed to the event bus.
+type Outbox struct {
+	ent.Schema
+}
+
+// Fields of the Outbox.
+func (Outbox) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.New()).Unique(),
+		field.String("topic"),
+		field.Text("payload"),
+		field.Time("createdAt"),
+		field.Time("deliveredAt").Optional().Nillable(),
+	}
+}
+
+// Indexes of the Outbox.
+func (Outbox) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("deliveredAt", "createdAt"),
+	}
+}
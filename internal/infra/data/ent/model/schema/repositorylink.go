@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// RepositoryLink holds the schema definition for the RepositoryLink entity.
+type RepositoryLink struct {
+	ent.Schema
+}
+
+// Fields of the RepositoryLink.
+func (RepositoryLink) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.New()).Unique(),
+		field.String("repository"),
+		field.String("branch"),
+		field.UUID("userID", uuid.New()),
+		field.UUID("taskID", uuid.New()),
+	}
+}
+
+// Edges of the RepositoryLink.
+func (RepositoryLink) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).Field("userID").
+			Ref("repositoryLinks").Unique().Required(),
+		edge.From("task", Task.Type).Field("taskID").
+			Ref("repositoryLinks").Unique().Required(),
+	}
+}
+
+// Indexes of the RepositoryLink.
+func (RepositoryLink) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("userID", "taskID").Unique(),
+		index.Fields("repository", "branch"),
+	}
+}
@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Result holds the schema definition for the Result entity.
+type Result struct {
+	ent.Schema
+}
+
+// Fields of the Result.
+func (Result) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.New()).Unique(),
+		field.Bool("passed"),
+		field.Text("error"),
+		field.Int("totalRequests"),
+		field.Int("failedRequests"),
+		// Latencies are stored in nanoseconds.
+		field.Int64("p50"),
+		field.Int64("p95"),
+		field.Int64("p99"),
+		field.UUID("submissionID", uuid.New()),
+		// SectionID is from the revision the submission was judged against.
+		// It isn't an edge, since the section could be deleted afterwards.
+		field.UUID("sectionID", uuid.New()),
+	}
+}
+
+// Edges of the Result.
+func (Result) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("submission", Submission.Type).Field("submissionID").
+			Ref("results").Unique().Required(),
+	}
+}
+
+// Indexes of the Result.
+func (Result) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("submissionID", "sectionID").Unique(),
+	}
+}
@@ -18,10 +18,18 @@ func (Submission) Fields() []ent.Field {
 		field.UUID("id", uuid.New()).Unique(),
 		field.Time("timestamp"),
 		field.Bool("isDone"),
+		// IsCancelled is set if the submission is cancelled, before or during execution.
+		field.Bool("isCancelled").Default(false),
 		field.String("repository"),
 		field.String("commitHash"),
+		// Score is set after the submission is tested.
+		field.Uint64("score").Optional().Nillable(),
 		field.UUID("userID", uuid.New()),
 		field.UUID("taskID", uuid.New()),
+		// Submissions made before revisions were introduced don't have it.
+		field.UUID("revisionID", uuid.New()).Optional().Nillable(),
+		// RejudgeOf is set if the submission is a re-run of another one.
+		field.UUID("rejudgeOf", uuid.New()).Optional().Nillable(),
 	}
 }
 
@@ -33,5 +41,8 @@ func (Submission) Edges() []ent.Edge {
 		edge.From("user", User.Type).Field("userID").
 			Ref("submissions").Unique().Required(),
 		edge.To("events", Event.Type),
+		edge.To("results", Result.Type),
+		edge.From("revision", TaskRevision.Type).Field("revisionID").
+			Ref("submissions").Unique(),
 	}
 }
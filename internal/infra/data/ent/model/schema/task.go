@@ -19,6 +19,12 @@ func (Task) Fields() []ent.Field {
 		field.String("title"),
 		field.String("description"),
 		field.String("stage"),
+		field.String("approvalRule").Default("MANUAL"),
+		// Only used by ALLOWED_ORGS rule.
+		field.Strings("allowedOrgs").Optional(),
+		// Submission limits per user on the task. Global limits are used if nil.
+		field.Uint64("submissionLimitHourly").Optional().Nillable(),
+		field.Uint64("submissionLimitDaily").Optional().Nillable(),
 	}
 }
 
@@ -28,5 +34,8 @@ func (Task) Edges() []ent.Edge {
 		edge.To("submissions", Submission.Type),
 		edge.To("sections", Section.Type),
 		edge.To("resources", Resource.Type),
+		edge.To("revisions", TaskRevision.Type),
+		edge.To("contests", ContestTask.Type),
+		edge.To("repositoryLinks", RepositoryLink.Type),
 	}
 }
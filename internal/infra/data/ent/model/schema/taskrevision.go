@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// TaskRevision holds the schema definition for the TaskRevision entity.
+type TaskRevision struct {
+	ent.Schema
+}
+
+// Fields of the TaskRevision.
+func (TaskRevision) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.New()).Unique(),
+		field.Uint32("number"),
+		field.Time("createdAt"),
+		// Snapshots are json-formatted and never updated.
+		field.Text("sections"),
+		field.Text("resources"),
+		field.UUID("taskID", uuid.New()),
+	}
+}
+
+// Edges of the TaskRevision.
+func (TaskRevision) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("task", Task.Type).Field("taskID").
+			Ref("revisions").Unique().Required(),
+		edge.To("submissions", Submission.Type),
+	}
+}
+
+// Indexes of the TaskRevision.
+func (TaskRevision) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("taskID", "number").Unique(),
+	}
+}
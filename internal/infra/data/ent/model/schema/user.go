@@ -20,6 +20,10 @@ func (User) Fields() []ent.Field {
 		field.String("email").Optional(),
 		field.String("profileURL"),
 		field.Uint8("role"),
+		// Exempted users are not limited on submissions.
+		field.Bool("limitExempt").Default(false),
+		// It is set after the user installs github app.
+		field.Int64("installationID").Optional().Nillable(),
 	}
 }
 
@@ -27,5 +31,6 @@ func (User) Fields() []ent.Field {
 func (User) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.To("submissions", Submission.Type),
+		edge.To("repositoryLinks", RepositoryLink.Type),
 	}
 }
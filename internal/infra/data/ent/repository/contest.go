@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/tx"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/datasource"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/model"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/model/contest"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/model/contesttask"
+)
+
+type ContestRepository struct {
+	*datasource.DataSource
+}
+
+var (
+	_ domain.ContestRepository = (*ContestRepository)(nil)
+	_ tx.DataSource            = (*ContestRepository)(nil)
+)
+
+func NewContestRepository(ds *datasource.DataSource) *ContestRepository {
+	return &ContestRepository{DataSource: ds}
+}
+
+func (r *ContestRepository) Create(ctx context.Context, contest domain.Contest) error {
+	client := r.DataSource.TxOrPlain(ctx)
+
+	err := client.Contest.
+		Create().
+		SetID(contest.ID).
+		SetTitle(contest.Title).
+		SetStartAt(contest.StartAt).
+		SetEndAt(contest.EndAt).
+		SetNillableFreezeAt(contest.FreezeAt).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.createTasks(ctx, contest)
+}
+
+func (r *ContestRepository) Update(ctx context.Context, contest domain.Contest) error {
+	client := r.DataSource.TxOrPlain(ctx)
+
+	update := client.Contest.
+		UpdateOneID(contest.ID).
+		SetTitle(contest.Title).
+		SetStartAt(contest.StartAt).
+		SetEndAt(contest.EndAt)
+
+	if contest.FreezeAt != nil {
+		update.SetFreezeAt(*contest.FreezeAt)
+	} else {
+		update.ClearFreezeAt()
+	}
+
+	if err := update.Exec(ctx); err != nil {
+		return err
+	}
+
+	_, err := client.ContestTask.
+		Delete().
+		Where(contesttask.ContestID(contest.ID)).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.createTasks(ctx, contest)
+}
+
+func (r *ContestRepository) createTasks(ctx context.Context, contest domain.Contest) error {
+	client := r.DataSource.TxOrPlain(ctx)
+
+	builders := make([]*model.ContestTaskCreate, len(contest.Tasks))
+	for idx, task := range contest.Tasks {
+		builders[idx] = client.ContestTask.
+			Create().
+			SetID(uuid.New()).
+			SetIndex(uint8(idx)).
+			SetContestID(contest.ID).
+			SetTaskID(task.ID)
+	}
+
+	return client.ContestTask.CreateBulk(builders...).Exec(ctx)
+}
+
+func (r *ContestRepository) FetchAll(ctx context.Context) ([]domain.Contest, error) {
+	models, err := r.DataSource.TxOrPlain(ctx).Contest.
+		Query().
+		Order(contest.ByStartAt(sql.OrderDesc())).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contests := make([]domain.Contest, len(models))
+	for idx, model := range models {
+		contests[idx] = toContest(model)
+	}
+
+	return contests, nil
+}
+
+func (r *ContestRepository) FetchAllByTaskID(ctx context.Context, taskID uuid.UUID) ([]domain.Contest, error) {
+	models, err := r.DataSource.TxOrPlain(ctx).Contest.
+		Query().
+		Where(contest.HasTasksWith(contesttask.TaskID(taskID))).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contests := make([]domain.Contest, len(models))
+	for idx, model := range models {
+		contests[idx] = toContest(model)
+	}
+
+	return contests, nil
+}
+
+func (r *ContestRepository) FetchByID(ctx context.Context, id uuid.UUID) (domain.Contest, error) {
+	entity, err := r.DataSource.TxOrPlain(ctx).Contest.
+		Query().
+		Where(contest.ID(id)).
+		WithTasks(func(q *model.ContestTaskQuery) {
+			q.WithTask().Order(contesttask.ByIndex(sql.OrderAsc()))
+		}).
+		Only(ctx)
+	if err != nil {
+		if model.IsNotFound(err) {
+			return domain.Contest{}, domain.ErrContestNotFound
+		}
+		return domain.Contest{}, err
+	}
+
+	contest := toContest(entity)
+
+	contest.Tasks = make([]domain.Task, len(entity.Edges.Tasks))
+	for idx, contestTask := range entity.Edges.Tasks {
+		task := contestTask.Edges.Task
+		contest.Tasks[idx] = domain.Task{
+			ID:          task.ID,
+			Title:       task.Title,
+			Description: task.Description,
+			Stage:       domain.TaskStage(task.Stage),
+		}
+	}
+
+	return contest, nil
+}
+
+func toContest(entity *model.Contest) domain.Contest {
+	return domain.Contest{
+		ID:       entity.ID,
+		Title:    entity.Title,
+		StartAt:  entity.StartAt,
+		EndAt:    entity.EndAt,
+		FreezeAt: entity.FreezeAt,
+	}
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/tx"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/datasource"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/model"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/model/repositorylink"
+)
+
+type RepositoryLinkRepository struct {
+	*datasource.DataSource
+}
+
+var (
+	_ domain.RepositoryLinkRepository = (*RepositoryLinkRepository)(nil)
+	_ tx.DataSource                   = (*RepositoryLinkRepository)(nil)
+)
+
+func NewRepositoryLinkRepository(ds *datasource.DataSource) *RepositoryLinkRepository {
+	return &RepositoryLinkRepository{DataSource: ds}
+}
+
+func (r *RepositoryLinkRepository) FetchByUserAndTask(ctx context.Context, userID, taskID uuid.UUID) (domain.RepositoryLink, error) {
+	entity, err := r.DataSource.TxOrPlain(ctx).RepositoryLink.
+		Query().
+		Where(
+			repositorylink.UserID(userID),
+			repositorylink.TaskID(taskID),
+		).
+		Only(ctx)
+	if err != nil {
+		if model.IsNotFound(err) {
+			return domain.RepositoryLink{}, domain.ErrRepositoryLinkNotFound
+		}
+		return domain.RepositoryLink{}, err
+	}
+
+	return toRepositoryLink(entity), nil
+}
+
+func (r *RepositoryLinkRepository) FetchAllByBranch(ctx context.Context, repository, branch string) ([]domain.RepositoryLink, error) {
+	models, err := r.DataSource.TxOrPlain(ctx).RepositoryLink.
+		Query().
+		Where(
+			repositorylink.RepositoryEqualFold(repository),
+			repositorylink.Branch(branch),
+		).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]domain.RepositoryLink, len(models))
+	for idx, model := range models {
+		links[idx] = toRepositoryLink(model)
+	}
+
+	return links, nil
+}
+
+func (r *RepositoryLinkRepository) Create(ctx context.Context, link domain.RepositoryLink) error {
+	return r.DataSource.TxOrPlain(ctx).RepositoryLink.
+		Create().
+		SetID(link.ID).
+		SetRepository(link.Repository).
+		SetBranch(link.Branch).
+		SetUserID(link.UserID).
+		SetTaskID(link.TaskID).
+		Exec(ctx)
+}
+
+func (r *RepositoryLinkRepository) Update(ctx context.Context, link domain.RepositoryLink) error {
+	return r.DataSource.TxOrPlain(ctx).RepositoryLink.
+		UpdateOneID(link.ID).
+		SetRepository(link.Repository).
+		SetBranch(link.Branch).
+		Exec(ctx)
+}
+
+func (r *RepositoryLinkRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.DataSource.TxOrPlain(ctx).RepositoryLink.
+		DeleteOneID(id).
+		Exec(ctx)
+}
+
+func toRepositoryLink(entity *model.RepositoryLink) domain.RepositoryLink {
+	return domain.RepositoryLink{
+		ID:         entity.ID,
+		Repository: entity.Repository,
+		Branch:     entity.Branch,
+		UserID:     entity.UserID,
+		TaskID:     entity.TaskID,
+	}
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/tx"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/datasource"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/model/outbox"
+	"github.com/pkg/errors"
+)
+
+// OutboxRepository publishes events by storing them into outbox table.
+// Since it shares transaction with other repositories,
+// events are stored only if the transaction commits.
+type OutboxRepository struct {
+	*datasource.DataSource
+}
+
+var (
+	_ event.Publisher     = (*OutboxRepository)(nil)
+	_ event.OutboxStorage = (*OutboxRepository)(nil)
+	_ tx.DataSource       = (*OutboxRepository)(nil)
+)
+
+func NewOutboxRepository(ds *datasource.DataSource) *OutboxRepository {
+	return &OutboxRepository{DataSource: ds}
+}
+
+func (r *OutboxRepository) Publish(ctx context.Context, topic event.Topic, e any) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "marshalling payload")
+	}
+
+	return r.DataSource.TxOrPlain(ctx).Outbox.
+		Create().
+		SetID(uuid.New()).
+		SetTopic(string(topic)).
+		SetPayload(string(payload)).
+		SetCreatedAt(time.Now()).
+		Exec(ctx)
+}
+
+func (r *OutboxRepository) FetchUndelivered(ctx context.Context, limit int) ([]event.OutboxMessage, error) {
+	models, err := r.DataSource.TxOrPlain(ctx).Outbox.
+		Query().
+		Where(outbox.DeliveredAtIsNil()).
+		Order(outbox.ByCreatedAt(sql.OrderAsc())).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]event.OutboxMessage, len(models))
+	for idx, model := range models {
+		messages[idx] = event.OutboxMessage{
+			ID:      model.ID,
+			Topic:   event.Topic(model.Topic),
+			Payload: []byte(model.Payload),
+		}
+	}
+
+	return messages, nil
+}
+
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id uuid.UUID) error {
+	return r.DataSource.TxOrPlain(ctx).Outbox.
+		UpdateOneID(id).
+		SetDeliveredAt(time.Now()).
+		Exec(ctx)
+}
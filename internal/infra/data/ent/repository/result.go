@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/tx"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/datasource"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/model"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/model/result"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/model/section"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/model/submission"
+)
+
+type ResultRepository struct {
+	*datasource.DataSource
+}
+
+var (
+	_ domain.ResultRepository = (*ResultRepository)(nil)
+	_ tx.DataSource           = (*ResultRepository)(nil)
+)
+
+func NewResultRepository(ds *datasource.DataSource) *ResultRepository {
+	return &ResultRepository{DataSource: ds}
+}
+
+func (r *ResultRepository) CreateBulk(ctx context.Context, results []domain.Result) error {
+	client := r.DataSource.TxOrPlain(ctx)
+
+	builders := make([]*model.ResultCreate, len(results))
+	for idx, res := range results {
+		builders[idx] = client.Result.
+			Create().
+			SetID(res.ID).
+			SetPassed(res.Passed).
+			SetError(res.Error).
+			SetTotalRequests(res.TotalRequests).
+			SetFailedRequests(res.FailedRequests).
+			SetP50(int64(res.P50)).
+			SetP95(int64(res.P95)).
+			SetP99(int64(res.P99)).
+			SetSubmissionID(res.SubmissionID).
+			SetSectionID(res.SectionID)
+	}
+
+	return client.Result.CreateBulk(builders...).Exec(ctx)
+}
+
+func (r *ResultRepository) FetchAllBySubmissionID(ctx context.Context, id uuid.UUID) ([]domain.Result, error) {
+	client := r.DataSource.TxOrPlain(ctx)
+
+	models, err := client.Result.
+		Query().
+		Where(result.SubmissionID(id)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(models) == 0 {
+		return []domain.Result{}, nil
+	}
+
+	sections, err := r.fetchJudgedSections(ctx, client, id)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.Result, len(models))
+	for idx, model := range models {
+		results[idx] = domain.Result{
+			ID:             model.ID,
+			Passed:         model.Passed,
+			Error:          model.Error,
+			TotalRequests:  model.TotalRequests,
+			FailedRequests: model.FailedRequests,
+			P50:            time.Duration(model.P50),
+			P95:            time.Duration(model.P95),
+			P99:            time.Duration(model.P99),
+			SubmissionID:   model.SubmissionID,
+			SectionID:      model.SectionID,
+		}
+
+		if section, ok := sections[model.SectionID]; ok {
+			results[idx].Section = &section
+		}
+	}
+
+	// Results of unknown sections go last.
+	slices.SortStableFunc(results, func(a, b domain.Result) int {
+		switch {
+		case a.Section == nil && b.Section == nil:
+			return 0
+		case a.Section == nil:
+			return 1
+		case b.Section == nil:
+			return -1
+		}
+
+		return cmp.Compare(a.Section.Index, b.Section.Index)
+	})
+
+	return results, nil
+}
+
+// fetchJudgedSections returns sections the submission was judged against, by their id.
+// They are from revision of the submission. Submissions without one use current sections of the task.
+func (r *ResultRepository) fetchJudgedSections(
+	ctx context.Context, client *model.Client, submissionID uuid.UUID,
+) (map[uuid.UUID]domain.Section, error) {
+	entity, err := client.Submission.
+		Query().
+		Where(submission.ID(submissionID)).
+		WithRevision().
+		Only(ctx)
+	if err != nil {
+		if model.IsNotFound(err) {
+			return nil, domain.ErrSubmissionNotFound
+		}
+		return nil, err
+	}
+
+	var sections []domain.Section
+
+	if entity.Edges.Revision != nil {
+		revision, err := toRevision(entity.Edges.Revision)
+		if err != nil {
+			return nil, err
+		}
+
+		sections = revision.Sections
+	} else {
+		models, err := client.Section.
+			Query().
+			Where(section.TaskID(entity.TaskID)).
+			// Don't include description, and example.
+			Select(
+				section.FieldID, section.FieldTitle, section.FieldIndex,
+				section.FieldType, section.FieldTaskID,
+			).
+			All(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sections = make([]domain.Section, len(models))
+		for idx, model := range models {
+			sections[idx] = domain.Section{
+				ID:     model.ID,
+				Title:  model.Title,
+				Index:  model.Index,
+				Type:   domain.SectionType(model.Type),
+				TaskID: model.TaskID,
+			}
+		}
+	}
+
+	byID := make(map[uuid.UUID]domain.Section, len(sections))
+	for _, section := range sections {
+		byID[section.ID] = section
+	}
+
+	return byID, nil
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/tx"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/datasource"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/model"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/model/taskrevision"
+	"github.com/pkg/errors"
+)
+
+// snapshotSection is stored format of domain.Section in revision.
+type snapshotSection struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Index       uint8     `json:"index"`
+	RPM         uint64    `json:"rpm"`
+	Type        string    `json:"type"`
+	Example     string    `json:"example"`
+}
+
+// snapshotResource is stored format of domain.Resource in revision.
+type snapshotResource struct {
+	Image     string  `json:"image"`
+	Name      string  `json:"name"`
+	Port      uint16  `json:"port"`
+	CPU       float64 `json:"cpu"`
+	Memory    uint64  `json:"memory"`
+	IsPrimary bool    `json:"isPrimary"`
+}
+
+type TaskRevisionRepository struct {
+	*datasource.DataSource
+}
+
+var (
+	_ domain.TaskRevisionRepository = (*TaskRevisionRepository)(nil)
+	_ tx.DataSource                 = (*TaskRevisionRepository)(nil)
+)
+
+func NewTaskRevisionRepository(ds *datasource.DataSource) *TaskRevisionRepository {
+	return &TaskRevisionRepository{DataSource: ds}
+}
+
+func (r *TaskRevisionRepository) Create(ctx context.Context, revision domain.TaskRevision) error {
+	sections := make([]snapshotSection, len(revision.Sections))
+	for idx, section := range revision.Sections {
+		sections[idx] = snapshotSection{
+			ID:          section.ID,
+			Title:       section.Title,
+			Description: section.Description,
+			Index:       section.Index,
+			RPM:         section.RPM,
+			Type:        string(section.Type),
+			Example:     section.Example,
+		}
+	}
+
+	resources := make([]snapshotResource, len(revision.Resources))
+	for idx, resource := range revision.Resources {
+		resources[idx] = snapshotResource{
+			Image:     resource.Image,
+			Name:      resource.Name,
+			Port:      resource.Port,
+			CPU:       resource.CPU,
+			Memory:    resource.Memory,
+			IsPrimary: resource.IsPrimary,
+		}
+	}
+
+	encodedSections, err := json.Marshal(sections)
+	if err != nil {
+		return errors.Wrap(err, "marshalling sections")
+	}
+
+	encodedResources, err := json.Marshal(resources)
+	if err != nil {
+		return errors.Wrap(err, "marshalling resources")
+	}
+
+	return r.DataSource.TxOrPlain(ctx).TaskRevision.
+		Create().
+		SetID(revision.ID).
+		SetNumber(revision.Number).
+		SetCreatedAt(revision.CreatedAt).
+		SetSections(string(encodedSections)).
+		SetResources(string(encodedResources)).
+		SetTaskID(revision.TaskID).
+		Exec(ctx)
+}
+
+func (r *TaskRevisionRepository) FetchAllByTaskID(ctx context.Context, taskID uuid.UUID) ([]domain.TaskRevision, error) {
+	models, err := r.DataSource.TxOrPlain(ctx).TaskRevision.
+		Query().
+		Where(taskrevision.TaskID(taskID)).
+		Order(taskrevision.ByNumber(sql.OrderDesc())).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]domain.TaskRevision, len(models))
+	for idx, model := range models {
+		revisions[idx], err = toRevision(model)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return revisions, nil
+}
+
+func (r *TaskRevisionRepository) FetchByID(ctx context.Context, id uuid.UUID) (domain.TaskRevision, error) {
+	entity, err := r.DataSource.TxOrPlain(ctx).TaskRevision.Get(ctx, id)
+	if err != nil {
+		if model.IsNotFound(err) {
+			return domain.TaskRevision{}, domain.ErrRevisionNotFound
+		}
+		return domain.TaskRevision{}, err
+	}
+
+	return toRevision(entity)
+}
+
+func (r *TaskRevisionRepository) FetchLatest(ctx context.Context, taskID uuid.UUID) (domain.TaskRevision, error) {
+	entity, err := r.DataSource.TxOrPlain(ctx).TaskRevision.
+		Query().
+		Where(taskrevision.TaskID(taskID)).
+		Order(taskrevision.ByNumber(sql.OrderDesc())).
+		First(ctx)
+	if err != nil {
+		if model.IsNotFound(err) {
+			return domain.TaskRevision{}, domain.ErrRevisionNotFound
+		}
+		return domain.TaskRevision{}, err
+	}
+
+	return toRevision(entity)
+}
+
+func toRevision(entity *model.TaskRevision) (domain.TaskRevision, error) {
+	var sections []snapshotSection
+	if err := json.Unmarshal([]byte(entity.Sections), &sections); err != nil {
+		return domain.TaskRevision{}, errors.Wrap(err, "unmarshalling sections")
+	}
+
+	var resources []snapshotResource
+	if err := json.Unmarshal([]byte(entity.Resources), &resources); err != nil {
+		return domain.TaskRevision{}, errors.Wrap(err, "unmarshalling resources")
+	}
+
+	revision := domain.TaskRevision{
+		ID:        entity.ID,
+		Number:    entity.Number,
+		CreatedAt: entity.CreatedAt,
+		Sections:  make([]domain.Section, len(sections)),
+		Resources: make([]domain.Resource, len(resources)),
+		TaskID:    entity.TaskID,
+	}
+
+	for idx, section := range sections {
+		revision.Sections[idx] = domain.Section{
+			ID:          section.ID,
+			Title:       section.Title,
+			Description: section.Description,
+			Index:       section.Index,
+			RPM:         section.RPM,
+			Type:        domain.SectionType(section.Type),
+			Example:     section.Example,
+			TaskID:      entity.TaskID,
+		}
+	}
+
+	for idx, resource := range resources {
+		revision.Resources[idx] = domain.Resource{
+			Image:     resource.Image,
+			Name:      resource.Name,
+			Port:      resource.Port,
+			CPU:       resource.CPU,
+			Memory:    resource.Memory,
+			IsPrimary: resource.IsPrimary,
+			TaskID:    entity.TaskID,
+		}
+	}
+
+	return revision, nil
+}
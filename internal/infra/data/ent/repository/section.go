@@ -116,7 +116,8 @@ func (r *SectionRepository) FetchAllByTaskID(ctx context.Context, taskID uuid.UU
 		// Don't include title, description, and example.
 		models, err = builder.Select(
 			section.FieldID, section.FieldIndex,
-			section.FieldType, section.FieldTaskID,
+			section.FieldType, section.FieldRpm,
+			section.FieldTaskID,
 		).All(ctx)
 	}
 
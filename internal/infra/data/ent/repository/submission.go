@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"github.com/google/uuid"
@@ -9,6 +10,7 @@ import (
 	"github.com/oneee-playground/r2d2-api-server/internal/global/tx"
 	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/datasource"
 	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/model"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/model/event"
 	"github.com/oneee-playground/r2d2-api-server/internal/infra/data/ent/model/submission"
 )
 
@@ -30,11 +32,15 @@ func (r *SubmissionRepository) Create(ctx context.Context, submission domain.Sub
 		Create().
 		SetID(submission.ID).
 		SetIsDone(submission.IsDone).
+		SetIsCancelled(submission.IsCancelled).
 		SetRepository(submission.Repository).
 		SetCommitHash(submission.CommitHash).
 		SetTimestamp(submission.Timestamp).
 		SetTaskID(submission.TaskID).
 		SetUserID(submission.UserID).
+		SetNillableScore(submission.Score).
+		SetNillableRevisionID(submission.RevisionID).
+		SetNillableRejudgeOf(submission.RejudgeOf).
 		Exec(ctx)
 }
 
@@ -48,18 +54,107 @@ func (r *SubmissionRepository) FetchByID(ctx context.Context, id uuid.UUID) (dom
 	}
 
 	submission := domain.Submission{
-		ID:         entity.ID,
-		Timestamp:  entity.Timestamp,
-		IsDone:     entity.IsDone,
-		Repository: entity.Repository,
-		CommitHash: entity.CommitHash,
-		TaskID:     entity.TaskID,
-		UserID:     entity.UserID,
+		ID:          entity.ID,
+		Timestamp:   entity.Timestamp,
+		IsDone:      entity.IsDone,
+		IsCancelled: entity.IsCancelled,
+		Repository:  entity.Repository,
+		CommitHash:  entity.CommitHash,
+		Score:       entity.Score,
+		TaskID:      entity.TaskID,
+		RevisionID:  entity.RevisionID,
+		RejudgeOf:   entity.RejudgeOf,
+		UserID:      entity.UserID,
 	}
 
 	return submission, nil
 }
 
+func (r *SubmissionRepository) ApprovedExists(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return r.DataSource.TxOrPlain(ctx).Submission.
+		Query().
+		Where(
+			submission.UserID(userID),
+			submission.HasEventsWith(event.Kind(string(domain.KindApprove))),
+		).Exist(ctx)
+}
+
+func (r *SubmissionRepository) FetchAllPending(ctx context.Context, filter domain.PendingFilter) ([]domain.Submission, error) {
+	query := r.DataSource.TxOrPlain(ctx).Submission.
+		Query().
+		Where(
+			submission.IsDone(false),
+			submission.Not(submission.HasEventsWith(event.KindNEQ(string(domain.KindSubmit)))),
+			// Re-runs are dispatched without approval.
+			submission.RejudgeOfIsNil(),
+		)
+
+	if filter.TaskID != nil {
+		query.Where(submission.TaskID(*filter.TaskID))
+	}
+	if filter.UserID != nil {
+		query.Where(submission.UserID(*filter.UserID))
+	}
+	if filter.SubmittedBefore != nil {
+		query.Where(submission.TimestampLTE(*filter.SubmittedBefore))
+	}
+
+	models, err := query.
+		WithUser().
+		Order(submission.ByTimestamp(sql.OrderAsc())).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSubmissionsWithUser(models), nil
+}
+
+func (r *SubmissionRepository) FetchAllApproved(ctx context.Context, taskID uuid.UUID, from, to *time.Time) ([]domain.Submission, error) {
+	query := r.DataSource.TxOrPlain(ctx).Submission.
+		Query().
+		Where(
+			submission.TaskID(taskID),
+			submission.RejudgeOfIsNil(),
+			submission.HasEventsWith(event.Kind(string(domain.KindApprove))),
+		)
+
+	if from != nil {
+		query.Where(submission.TimestampGTE(*from))
+	}
+	if to != nil {
+		query.Where(submission.TimestampLTE(*to))
+	}
+
+	models, err := query.
+		WithUser().
+		Order(submission.ByTimestamp(sql.OrderDesc())).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSubmissionsWithUser(models), nil
+}
+
+func (r *SubmissionRepository) FetchQueuedRejudges(ctx context.Context, limit int) ([]domain.Submission, error) {
+	models, err := r.DataSource.TxOrPlain(ctx).Submission.
+		Query().
+		Where(
+			submission.IsDone(false),
+			submission.RejudgeOfNotNil(),
+		).
+		WithUser().
+		Order(submission.ByTimestamp(sql.OrderAsc())).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSubmissionsWithUser(models), nil
+}
+
 func (r *SubmissionRepository) UndoneExists(ctx context.Context, taskID uuid.UUID, userID uuid.UUID) (bool, error) {
 	return r.DataSource.TxOrPlain(ctx).Submission.
 		Query().
@@ -68,17 +163,60 @@ func (r *SubmissionRepository) UndoneExists(ctx context.Context, taskID uuid.UUI
 				submission.TaskID(taskID),
 				submission.UserID(userID),
 				submission.IsDone(false),
+				// Queued rejudges are not made by the user.
+				submission.RejudgeOfIsNil(),
 			),
 		).Exist(ctx)
 }
 
+func (r *SubmissionRepository) FetchAllUndone(ctx context.Context, taskID uuid.UUID) ([]domain.Submission, error) {
+	models, err := r.DataSource.TxOrPlain(ctx).Submission.
+		Query().
+		Where(
+			submission.And(
+				submission.TaskID(taskID),
+				submission.IsDone(false),
+			),
+		).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSubmissions(models), nil
+}
+
+func (r *SubmissionRepository) FetchAllRunning(ctx context.Context, taskID uuid.UUID) ([]domain.Submission, error) {
+	finishing := make([]string, len(domain.FinishingKinds))
+	for idx, kind := range domain.FinishingKinds {
+		finishing[idx] = string(kind)
+	}
+
+	models, err := r.DataSource.TxOrPlain(ctx).Submission.
+		Query().
+		Where(
+			submission.TaskID(taskID),
+			submission.IsCancelled(false),
+			submission.HasEventsWith(event.Kind(string(domain.KindApprove))),
+			submission.Not(submission.HasEventsWith(event.KindIn(finishing...))),
+		).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSubmissions(models), nil
+}
+
 func (r *SubmissionRepository) Update(ctx context.Context, submission domain.Submission) error {
 	return r.DataSource.TxOrPlain(ctx).Submission.
 		UpdateOneID(submission.ID).
 		SetIsDone(submission.IsDone).
+		SetIsCancelled(submission.IsCancelled).
 		SetRepository(submission.Repository).
 		SetCommitHash(submission.CommitHash).
 		SetTimestamp(submission.Timestamp).
+		SetNillableScore(submission.Score).
 		Exec(ctx)
 }
 
@@ -95,16 +233,68 @@ func (r *SubmissionRepository) FetchPaginated(ctx context.Context, taskID uuid.U
 		return nil, err
 	}
 
+	return toSubmissionsWithUser(models), nil
+}
+
+func (r *SubmissionRepository) FetchAllScored(ctx context.Context, taskID uuid.UUID) ([]domain.Submission, error) {
+	models, err := r.DataSource.TxOrPlain(ctx).Submission.
+		Query().
+		Where(
+			submission.And(
+				submission.TaskID(taskID),
+				submission.ScoreNotNil(),
+			),
+		).
+		WithUser().
+		Order(
+			submission.ByScore(sql.OrderDesc()),
+			submission.ByTimestamp(sql.OrderAsc()),
+		).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSubmissionsWithUser(models), nil
+}
+
+// toSubmissionsWithUser converts models queried with user edge.
+func toSubmissions(models []*model.Submission) []domain.Submission {
+	submissions := make([]domain.Submission, len(models))
+	for idx, model := range models {
+		submissions[idx] = domain.Submission{
+			ID:          model.ID,
+			Timestamp:   model.Timestamp,
+			IsDone:      model.IsDone,
+			IsCancelled: model.IsCancelled,
+			Repository:  model.Repository,
+			CommitHash:  model.CommitHash,
+			Score:       model.Score,
+			TaskID:      model.TaskID,
+			RevisionID:  model.RevisionID,
+			RejudgeOf:   model.RejudgeOf,
+			UserID:      model.UserID,
+		}
+	}
+
+	return submissions
+}
+
+func toSubmissionsWithUser(models []*model.Submission) []domain.Submission {
 	submissions := make([]domain.Submission, len(models))
 	for idx, model := range models {
 		submissions[idx] = domain.Submission{
-			ID:         model.ID,
-			Timestamp:  model.Timestamp,
-			IsDone:     model.IsDone,
-			Repository: model.Repository,
-			CommitHash: model.CommitHash,
-			TaskID:     model.TaskID,
-			UserID:     model.UserID,
+			ID:          model.ID,
+			Timestamp:   model.Timestamp,
+			IsDone:      model.IsDone,
+			IsCancelled: model.IsCancelled,
+			Repository:  model.Repository,
+			CommitHash:  model.CommitHash,
+			Score:       model.Score,
+			TaskID:      model.TaskID,
+			RevisionID:  model.RevisionID,
+			RejudgeOf:   model.RejudgeOf,
+			UserID:      model.UserID,
 			User: &domain.User{
 				ID:         model.Edges.User.ID,
 				Username:   model.Edges.User.Username,
@@ -115,5 +305,5 @@ func (r *SubmissionRepository) FetchPaginated(ctx context.Context, taskID uuid.U
 		}
 	}
 
-	return submissions, nil
+	return submissions
 }
@@ -25,13 +25,22 @@ func NewTaskRepository(ds *datasource.DataSource) *TaskRepository {
 }
 
 func (r *TaskRepository) Create(ctx context.Context, task domain.Task) error {
-	return r.DataSource.TxOrPlain(ctx).Task.
+	create := r.DataSource.TxOrPlain(ctx).Task.
 		Create().
 		SetID(task.ID).
 		SetTitle(task.Title).
 		SetDescription(task.Description).
 		SetStage(string(task.Stage)).
-		Exec(ctx)
+		SetAllowedOrgs(task.ApprovalPolicy.AllowedOrgs).
+		SetNillableSubmissionLimitHourly(task.SubmissionLimit.Hourly).
+		SetNillableSubmissionLimitDaily(task.SubmissionLimit.Daily)
+
+	// Rule defaults to manual.
+	if task.ApprovalPolicy.Rule != "" {
+		create.SetApprovalRule(string(task.ApprovalPolicy.Rule))
+	}
+
+	return create.Exec(ctx)
 }
 
 func (r *TaskRepository) ExistsByID(ctx context.Context, id uuid.UUID) (bool, error) {
@@ -49,12 +58,7 @@ func (r *TaskRepository) FetchAll(ctx context.Context) ([]domain.Task, error) {
 
 	tasks := make([]domain.Task, len(models))
 	for idx, model := range models {
-		tasks[idx] = domain.Task{
-			ID:          model.ID,
-			Title:       model.Title,
-			Description: model.Description,
-			Stage:       domain.TaskStage(model.Stage),
-		}
+		tasks[idx] = toTask(model)
 	}
 
 	return tasks, nil
@@ -69,21 +73,47 @@ func (r *TaskRepository) FetchByID(ctx context.Context, id uuid.UUID) (domain.Ta
 		return domain.Task{}, err
 	}
 
-	task := domain.Task{
-		ID:          entity.ID,
-		Title:       entity.Title,
-		Description: entity.Description,
-		Stage:       domain.TaskStage(entity.Stage),
-	}
-
-	return task, nil
+	return toTask(entity), nil
 }
 
 func (r *TaskRepository) Update(ctx context.Context, task domain.Task) error {
-	return r.DataSource.TxOrPlain(ctx).Task.
+	update := r.DataSource.TxOrPlain(ctx).Task.
 		UpdateOneID(task.ID).
 		SetTitle(task.Title).
 		SetDescription(task.Description).
 		SetStage(string(task.Stage)).
-		Exec(ctx)
+		SetApprovalRule(string(task.ApprovalPolicy.Rule)).
+		SetAllowedOrgs(task.ApprovalPolicy.AllowedOrgs)
+
+	// Nil limits are cleared to fall back to global limits.
+	if task.SubmissionLimit.Hourly != nil {
+		update.SetSubmissionLimitHourly(*task.SubmissionLimit.Hourly)
+	} else {
+		update.ClearSubmissionLimitHourly()
+	}
+
+	if task.SubmissionLimit.Daily != nil {
+		update.SetSubmissionLimitDaily(*task.SubmissionLimit.Daily)
+	} else {
+		update.ClearSubmissionLimitDaily()
+	}
+
+	return update.Exec(ctx)
+}
+
+func toTask(entity *model.Task) domain.Task {
+	return domain.Task{
+		ID:          entity.ID,
+		Title:       entity.Title,
+		Description: entity.Description,
+		Stage:       domain.TaskStage(entity.Stage),
+		ApprovalPolicy: domain.ApprovalPolicy{
+			Rule:        domain.ApprovalRule(entity.ApprovalRule),
+			AllowedOrgs: entity.AllowedOrgs,
+		},
+		SubmissionLimit: domain.SubmissionLimit{
+			Hourly: entity.SubmissionLimitHourly,
+			Daily:  entity.SubmissionLimitDaily,
+		},
+	}
 }
@@ -31,6 +31,8 @@ func (r *UserRepository) Create(ctx context.Context, user domain.User) error {
 		SetEmail(user.Email).
 		SetProfileURL(user.ProfileURL).
 		SetRole(uint8(user.Role)).
+		SetLimitExempt(user.LimitExempt).
+		SetNillableInstallationID(user.InstallationID).
 		Exec(ctx)
 }
 
@@ -44,11 +46,13 @@ func (r *UserRepository) FetchByID(ctx context.Context, id uuid.UUID) (domain.Us
 	}
 
 	user := domain.User{
-		ID:         entity.ID,
-		Username:   entity.Username,
-		Email:      entity.Email,
-		ProfileURL: entity.ProfileURL,
-		Role:       domain.UserRole(entity.Role),
+		ID:             entity.ID,
+		Username:       entity.Username,
+		Email:          entity.Email,
+		ProfileURL:     entity.ProfileURL,
+		Role:           domain.UserRole(entity.Role),
+		LimitExempt:    entity.LimitExempt,
+		InstallationID: entity.InstallationID,
 	}
 
 	return user, nil
@@ -67,11 +71,13 @@ func (r *UserRepository) FetchByUsername(ctx context.Context, username string) (
 	}
 
 	user := domain.User{
-		ID:         entity.ID,
-		Username:   entity.Username,
-		Email:      entity.Email,
-		ProfileURL: entity.ProfileURL,
-		Role:       domain.UserRole(entity.Role),
+		ID:             entity.ID,
+		Username:       entity.Username,
+		Email:          entity.Email,
+		ProfileURL:     entity.ProfileURL,
+		Role:           domain.UserRole(entity.Role),
+		LimitExempt:    entity.LimitExempt,
+		InstallationID: entity.InstallationID,
 	}
 
 	return user, nil
@@ -83,3 +89,21 @@ func (r *UserRepository) UsernameExists(ctx context.Context, username string) (b
 		Where(user.Username(username)).
 		Exist(ctx)
 }
+
+func (r *UserRepository) Update(ctx context.Context, user domain.User) error {
+	update := r.DataSource.TxOrPlain(ctx).User.
+		UpdateOneID(user.ID).
+		SetUsername(user.Username).
+		SetEmail(user.Email).
+		SetProfileURL(user.ProfileURL).
+		SetRole(uint8(user.Role)).
+		SetLimitExempt(user.LimitExempt)
+
+	if user.InstallationID != nil {
+		update.SetInstallationID(*user.InstallationID)
+	} else {
+		update.ClearInstallationID()
+	}
+
+	return update.Exec(ctx)
+}
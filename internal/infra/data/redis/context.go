@@ -2,6 +2,8 @@ package redis
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	exec_module "github.com/oneee-playground/r2d2-api-server/internal/module/exec"
@@ -10,6 +12,25 @@ import (
 
 const _execContextKey = "exec-context"
 
+// _cancelledPlaceholderTTL is how long cancelled context is kept when execution has not started.
+// It only has to outlive the start of execution racing with cancellation.
+const _cancelledPlaceholderTTL = 24 * time.Hour
+
+// cancelScript sets "cancelled" of the context and returns 1.
+// If there is no context, it stores ARGV[1] for ARGV[2] milliseconds and returns 0.
+var cancelScript = rueidis.NewLuaScript(`
+local value = redis.call("GET", KEYS[1])
+if not value then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+	return 0
+end
+
+local decoded = cjson.decode(value)
+decoded["cancelled"] = true
+redis.call("SET", KEYS[1], cjson.encode(decoded), "KEEPTTL")
+return 1
+`)
+
 type RedisExecContextStroage struct {
 	client rueidis.Client
 }
@@ -28,20 +49,48 @@ func (s *RedisExecContextStroage) Get(ctx context.Context, submissionID uuid.UUI
 
 	var decoded exec_module.ExecContext
 	if err := s.client.Do(ctx, cmd).DecodeJSON(&decoded); err != nil {
+		if rueidis.IsRedisNil(err) {
+			return exec_module.ExecContext{}, exec_module.ErrContextNotFound
+		}
 		return exec_module.ExecContext{}, err
 	}
 
 	return decoded, nil
 }
 
-func (s *RedisExecContextStroage) Set(ctx context.Context, submissionID uuid.UUID, execCtx exec_module.ExecContext) error {
+func (s *RedisExecContextStroage) Create(ctx context.Context, submissionID uuid.UUID, execCtx exec_module.ExecContext) (bool, error) {
 	cmd := s.client.B().
 		Set().
 		Key(s.buildExecCtxKey(submissionID)).
 		Value(rueidis.JSON(execCtx)).
+		Nx().
 		Build()
 
-	return s.client.Do(ctx, cmd).Error()
+	err := s.client.Do(ctx, cmd).Error()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			// Key already exists.
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *RedisExecContextStroage) Cancel(ctx context.Context, submissionID uuid.UUID) (bool, error) {
+	keys := []string{s.buildExecCtxKey(submissionID)}
+	args := []string{
+		rueidis.JSON(exec_module.ExecContext{Cancelled: true}),
+		strconv.FormatInt(_cancelledPlaceholderTTL.Milliseconds(), 10),
+	}
+
+	existed, err := cancelScript.Exec(ctx, s.client, keys, args).AsInt64()
+	if err != nil {
+		return false, err
+	}
+
+	return existed == 1, nil
 }
 
 func (s *RedisExecContextStroage) Delete(ctx context.Context, submissionID uuid.UUID) error {
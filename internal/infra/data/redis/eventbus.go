@@ -0,0 +1,323 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	"github.com/pkg/errors"
+	"github.com/redis/rueidis"
+	"go.uber.org/zap"
+)
+
+const (
+	_eventStreamKey = "event-stream"
+	_payloadField   = "payload"
+)
+
+const (
+	// Creating consumer group is retried with backoff, doubling from base to max.
+	_groupBaseBackoff = time.Second
+	_groupMaxBackoff  = time.Minute
+)
+
+type StreamOptions struct {
+	// Group is consumer group shared by all server instances.
+	Group string
+	// Consumer is name of this server instance within the group.
+	// It should be unique across instances.
+	Consumer string
+
+	// BatchSize is the maximum number of entries read at once.
+	BatchSize int64
+	// BlockTimeout is how long XREADGROUP waits for new entries.
+	BlockTimeout time.Duration
+	// ClaimMinIdle is how long an entry should stay pending
+	// before other consumers can claim it with XAUTOCLAIM.
+	ClaimMinIdle time.Duration
+}
+
+// RedisStreamEventBus is event bus based on redis streams.
+// Each topic is mapped to one stream, and entries are acknowledged
+// only after every handler succeeds (or skips).
+type RedisStreamEventBus struct {
+	client rueidis.Client
+	logger *zap.Logger
+	opts   StreamOptions
+
+	topics []event.Topic
+
+	mu       sync.RWMutex
+	handlers map[event.Topic][]event.HandlerFunc
+}
+
+var (
+	_ event.Subscriber = (*RedisStreamEventBus)(nil)
+	_ event.Publisher  = (*RedisStreamEventBus)(nil)
+)
+
+func NewRedisStreamEventBus(client rueidis.Client, logger *zap.Logger, opts StreamOptions, topics ...event.Topic) *RedisStreamEventBus {
+	return &RedisStreamEventBus{
+		client:   client,
+		logger:   logger,
+		opts:     opts,
+		topics:   topics,
+		handlers: make(map[event.Topic][]event.HandlerFunc),
+	}
+}
+
+func (b *RedisStreamEventBus) Publish(ctx context.Context, topic event.Topic, e any) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "marshalling payload")
+	}
+
+	cmd := b.client.B().
+		Xadd().
+		Key(b.buildStreamKey(topic)).
+		Id("*").
+		FieldValue().FieldValue(_payloadField, string(payload)).
+		Build()
+
+	if err := b.client.Do(ctx, cmd).Error(); err != nil {
+		return errors.Wrap(err, "adding entry to stream")
+	}
+
+	return nil
+}
+
+func (b *RedisStreamEventBus) Subscribe(ctx context.Context, topic event.Topic, handlers ...event.HandlerFunc) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[topic] = append(b.handlers[topic], handlers...)
+	return nil
+}
+
+// Listen creates consumer groups and reads entries of all topics.
+// It is required to call it within seperate goroutine since it blocks the flow.
+func (b *RedisStreamEventBus) Listen(ctx context.Context) {
+	b.logger.Info("started listening topics")
+
+	var wg sync.WaitGroup
+
+	for _, topic := range b.topics {
+		wg.Add(1)
+		go b.listenTopic(ctx, &wg, topic)
+	}
+
+	wg.Wait()
+}
+
+// ensureGroup creates consumer group of the topic, retrying until it succeeds.
+// It reports false if ctx is done before that.
+func (b *RedisStreamEventBus) ensureGroup(ctx context.Context, topic event.Topic) bool {
+	for attempt := 0; ; attempt++ {
+		err := b.createGroup(ctx, topic)
+		if err == nil {
+			return true
+		}
+
+		wait := groupBackoff(attempt)
+		b.logger.Error("failed to create consumer group",
+			zap.String("topic", string(topic)),
+			zap.Duration("retryAfter", wait),
+			zap.Error(err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(wait):
+		}
+	}
+}
+
+// groupBackoff returns how long to wait before next attempt of creating consumer group.
+func groupBackoff(attempt int) time.Duration {
+	wait := _groupBaseBackoff
+	for i := 0; i < attempt && wait < _groupMaxBackoff; i++ {
+		wait *= 2
+	}
+
+	return min(wait, _groupMaxBackoff)
+}
+
+func (b *RedisStreamEventBus) createGroup(ctx context.Context, topic event.Topic) error {
+	cmd := b.client.B().
+		XgroupCreate().
+		Key(b.buildStreamKey(topic)).
+		Group(b.opts.Group).
+		Id("$").
+		Mkstream().
+		Build()
+
+	err := b.client.Do(ctx, cmd).Error()
+	if err != nil && !rueidis.IsRedisBusyGroup(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (b *RedisStreamEventBus) listenTopic(ctx context.Context, wg *sync.WaitGroup, topic event.Topic) {
+	defer wg.Done()
+
+	if !b.ensureGroup(ctx, topic) {
+		b.logger.Error("context done", zap.Error(ctx.Err()))
+		return
+	}
+
+	for {
+		if ctx.Err() != nil {
+			b.logger.Error("context done", zap.Error(ctx.Err()))
+			return
+		}
+
+		// Entries left pending by crashed (or failing) consumers come first.
+		claimed, err := b.claimPending(ctx, topic)
+		if err != nil {
+			b.logger.Error("failed to claim pending entries",
+				zap.String("topic", string(topic)),
+				zap.Error(err),
+			)
+		}
+		b.handleEntries(ctx, topic, claimed)
+
+		entries, err := b.readNew(ctx, topic)
+		if err != nil {
+			b.logger.Error("failed to read entries",
+				zap.String("topic", string(topic)),
+				zap.Error(err),
+			)
+
+			// Prevent busy loop when redis is unavailable.
+			select {
+			case <-ctx.Done():
+				b.logger.Error("context done", zap.Error(ctx.Err()))
+				return
+			case <-time.After(b.opts.BlockTimeout):
+			}
+			continue
+		}
+		b.handleEntries(ctx, topic, entries)
+	}
+}
+
+func (b *RedisStreamEventBus) claimPending(ctx context.Context, topic event.Topic) ([]rueidis.XRangeEntry, error) {
+	cmd := b.client.B().
+		Xautoclaim().
+		Key(b.buildStreamKey(topic)).
+		Group(b.opts.Group).
+		Consumer(b.opts.Consumer).
+		MinIdleTime(strconv.FormatInt(b.opts.ClaimMinIdle.Milliseconds(), 10)).
+		Start("0-0").
+		Count(b.opts.BatchSize).
+		Build()
+
+	// Reply is formed as [next-start-id, entries, deleted-ids].
+	reply, err := b.client.Do(ctx, cmd).ToArray()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reply) < 2 {
+		return nil, errors.Errorf("unexpected xautoclaim reply length: %d", len(reply))
+	}
+
+	return reply[1].AsXRange()
+}
+
+func (b *RedisStreamEventBus) readNew(ctx context.Context, topic event.Topic) ([]rueidis.XRangeEntry, error) {
+	stream := b.buildStreamKey(topic)
+
+	cmd := b.client.B().
+		Xreadgroup().
+		Group(b.opts.Group, b.opts.Consumer).
+		Count(b.opts.BatchSize).
+		Block(b.opts.BlockTimeout.Milliseconds()).
+		Streams().
+		Key(stream).
+		Id(">").
+		Build()
+
+	streams, err := b.client.Do(ctx, cmd).AsXRead()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			// Nothing arrived within block timeout.
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return streams[stream], nil
+}
+
+func (b *RedisStreamEventBus) handleEntries(ctx context.Context, topic event.Topic, entries []rueidis.XRangeEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	toAck := b.processEntries(ctx, topic, entries)
+	if len(toAck) == 0 {
+		return
+	}
+
+	cmd := b.client.B().
+		Xack().
+		Key(b.buildStreamKey(topic)).
+		Group(b.opts.Group).
+		Id(toAck...).
+		Build()
+
+	if err := b.client.Do(ctx, cmd).Error(); err != nil {
+		b.logger.Error("failed to acknowledge entries",
+			zap.String("topic", string(topic)),
+			zap.Error(err),
+		)
+	}
+}
+
+// processEntries calls handlers of the topic with each entry, and returns ids of entries to acknowledge.
+// Entries failed by any handler are left pending, so they are claimed and redelivered later.
+func (b *RedisStreamEventBus) processEntries(ctx context.Context, topic event.Topic, entries []rueidis.XRangeEntry) []string {
+	b.mu.RLock()
+	handlers := b.handlers[topic]
+	b.mu.RUnlock()
+
+	toAck := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		payload := []byte(entry.FieldValues[_payloadField])
+
+		succeeded := true
+		for _, f := range handlers {
+			err := f(ctx, topic, payload)
+			if err == event.NoErrSkipHandler {
+				continue
+			}
+
+			if err != nil {
+				succeeded = false
+				b.logger.Error("failed to handle message",
+					zap.String("topic", string(topic)),
+					zap.String("entryID", entry.ID),
+					zap.Error(err),
+				)
+			}
+		}
+
+		if succeeded {
+			toAck = append(toAck, entry.ID)
+		}
+	}
+
+	return toAck
+}
+
+func (b *RedisStreamEventBus) buildStreamKey(topic event.Topic) string {
+	return buildKey(_eventStreamKey, string(topic))
+}
@@ -0,0 +1,85 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	"github.com/pkg/errors"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestGroupBackoff(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		attempt  int
+		expected time.Duration
+	}{
+		{desc: "first failure", attempt: 0, expected: time.Second},
+		{desc: "doubled", attempt: 3, expected: 8 * time.Second},
+		{desc: "capped", attempt: 10, expected: time.Minute},
+	}
+
+	for _, tc := range testcases {
+		assert.Equal(t, tc.expected, groupBackoff(tc.attempt), tc.desc)
+	}
+}
+
+func TestProcessEntries(t *testing.T) {
+	newEntry := func(id, payload string) rueidis.XRangeEntry {
+		return rueidis.XRangeEntry{ID: id, FieldValues: map[string]string{_payloadField: payload}}
+	}
+
+	var (
+		ok      = newEntry("1-0", "ok")
+		skipped = newEntry("2-0", "skip")
+		failed  = newEntry("3-0", "fail")
+	)
+
+	// Failing entries succeed on redelivery.
+	delivered := make(map[string]int)
+
+	bus := NewRedisStreamEventBus(nil, zap.NewNop(), StreamOptions{}, event.TopicSubmission)
+	bus.Subscribe(context.Background(), event.TopicSubmission,
+		func(ctx context.Context, topic event.Topic, payload []byte) error {
+			delivered[string(payload)]++
+			return nil
+		},
+		func(ctx context.Context, topic event.Topic, payload []byte) error {
+			switch string(payload) {
+			case "skip":
+				return event.NoErrSkipHandler
+			case "fail":
+				if delivered["fail"] == 1 {
+					return errors.New("handler failed")
+				}
+			}
+			return nil
+		},
+	)
+
+	// New entries.
+	toAck := bus.processEntries(context.Background(), event.TopicSubmission,
+		[]rueidis.XRangeEntry{ok, skipped, failed},
+	)
+	assert.Equal(t, []string{ok.ID, skipped.ID}, toAck, "failed entry should be left pending")
+
+	// Pending entry is claimed and redelivered.
+	toAck = bus.processEntries(context.Background(), event.TopicSubmission,
+		[]rueidis.XRangeEntry{failed},
+	)
+	assert.Equal(t, []string{failed.ID}, toAck, "redelivered entry should be acknowledged")
+	assert.Equal(t, 2, delivered["fail"])
+}
+
+func TestProcessEntriesWithoutHandler(t *testing.T) {
+	bus := NewRedisStreamEventBus(nil, zap.NewNop(), StreamOptions{}, event.TopicSubmission)
+
+	entry := rueidis.XRangeEntry{ID: "1-0", FieldValues: map[string]string{_payloadField: "{}"}}
+
+	toAck := bus.processEntries(context.Background(), event.TopicSubmission, []rueidis.XRangeEntry{entry})
+	assert.Equal(t, []string{entry.ID}, toAck)
+}
@@ -0,0 +1,94 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	"github.com/redis/rueidis"
+)
+
+const _processedEventKey = "processed-event"
+
+const (
+	_ledgerProcessing = "processing"
+	_ledgerDone       = "done"
+)
+
+// claimScript returns 0 if the event is done, -1 if it is being processed.
+// Otherwise, it claims the event for ARGV[1] milliseconds and returns 1.
+var claimScript = rueidis.NewLuaScript(`
+local value = redis.call("GET", KEYS[1])
+if value == "` + _ledgerDone + `" then
+	return 0
+end
+if value then
+	return -1
+end
+
+redis.call("SET", KEYS[1], "` + _ledgerProcessing + `", "PX", ARGV[1])
+return 1
+`)
+
+type RedisLedger struct {
+	client rueidis.Client
+
+	// processingTTL is how long claims are kept while processing.
+	// It should be longer than any handler runs.
+	processingTTL time.Duration
+
+	// ttl is how long records are kept.
+	// It should be longer than any redelivery could happen.
+	ttl time.Duration
+}
+
+var _ event.Ledger = (*RedisLedger)(nil)
+
+func NewLedger(client rueidis.Client, processingTTL, ttl time.Duration) *RedisLedger {
+	return &RedisLedger{client: client, processingTTL: processingTTL, ttl: ttl}
+}
+
+func (l *RedisLedger) Claim(ctx context.Context, handler string, eventID uuid.UUID) (bool, error) {
+	keys := []string{l.buildLedgerKey(handler, eventID)}
+	args := []string{strconv.FormatInt(l.processingTTL.Milliseconds(), 10)}
+
+	result, err := claimScript.Exec(ctx, l.client, keys, args).AsInt64()
+	if err != nil {
+		return false, err
+	}
+
+	switch result {
+	case 0:
+		return false, nil
+	case -1:
+		return false, event.ErrEventInProgress
+	}
+
+	return true, nil
+}
+
+func (l *RedisLedger) Complete(ctx context.Context, handler string, eventID uuid.UUID) error {
+	cmd := l.client.B().
+		Set().
+		Key(l.buildLedgerKey(handler, eventID)).
+		Value(_ledgerDone).
+		Ex(l.ttl).
+		Build()
+
+	return l.client.Do(ctx, cmd).Error()
+}
+
+func (l *RedisLedger) Release(ctx context.Context, handler string, eventID uuid.UUID) error {
+	cmd := l.client.B().
+		Del().
+		Key(l.buildLedgerKey(handler, eventID)).
+		Build()
+
+	return l.client.Do(ctx, cmd).Error()
+}
+
+func (l *RedisLedger) buildLedgerKey(handler string, eventID uuid.UUID) string {
+	return buildKey(_processedEventKey, handler, eventID.String())
+}
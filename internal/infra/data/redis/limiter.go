@@ -0,0 +1,88 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	submission_module "github.com/oneee-playground/r2d2-api-server/internal/module/submission"
+	"github.com/redis/rueidis"
+)
+
+const _submissionLimitKey = "submission-limit"
+
+// takeScript checks every counter first, so nothing is counted on rejection.
+// ARGV has count and window(ms) of each key in turn.
+// It returns -1 if taken. Otherwise, milliseconds until the exhausted counter expires.
+var takeScript = rueidis.NewLuaScript(`
+for i, key in ipairs(KEYS) do
+	local count = tonumber(redis.call("GET", key) or "0")
+	if count >= tonumber(ARGV[2*i-1]) then
+		return math.max(redis.call("PTTL", key), 1)
+	end
+end
+
+for i, key in ipairs(KEYS) do
+	if redis.call("INCR", key) == 1 then
+		redis.call("PEXPIRE", key, ARGV[2*i])
+	end
+end
+
+return -1
+`)
+
+// refundScript decrements counters which are not expired yet.
+var refundScript = rueidis.NewLuaScript(`
+for _, key in ipairs(KEYS) do
+	if tonumber(redis.call("GET", key) or "0") > 0 then
+		redis.call("DECR", key)
+	end
+end
+
+return 0
+`)
+
+// RedisRateLimiter counts submissions in fixed windows,
+// which start on the first submission.
+type RedisRateLimiter struct {
+	client rueidis.Client
+}
+
+var _ submission_module.RateLimiter = (*RedisRateLimiter)(nil)
+
+func NewRateLimiter(client rueidis.Client) *RedisRateLimiter {
+	return &RedisRateLimiter{client: client}
+}
+
+func (l *RedisRateLimiter) Take(ctx context.Context, limits []submission_module.Limit) (bool, time.Duration, error) {
+	keys := make([]string, len(limits))
+	args := make([]string, 0, 2*len(limits))
+
+	for idx, limit := range limits {
+		keys[idx] = buildKey(_submissionLimitKey, limit.Key)
+		args = append(args,
+			strconv.FormatUint(limit.Count, 10),
+			strconv.FormatInt(limit.Window.Milliseconds(), 10),
+		)
+	}
+
+	wait, err := takeScript.Exec(ctx, l.client, keys, args).AsInt64()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if wait < 0 {
+		return true, 0, nil
+	}
+
+	return false, time.Duration(wait) * time.Millisecond, nil
+}
+
+func (l *RedisRateLimiter) Refund(ctx context.Context, limits []submission_module.Limit) error {
+	keys := make([]string, len(limits))
+	for idx, limit := range limits {
+		keys[idx] = buildKey(_submissionLimitKey, limit.Key)
+	}
+
+	return refundScript.Exec(ctx, l.client, keys, nil).Error()
+}
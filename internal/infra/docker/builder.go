@@ -0,0 +1,182 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	exec_module "github.com/oneee-playground/r2d2-api-server/internal/module/exec"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// maxLogLength is the maximum length of build log included in the event.
+const maxLogLength = 2000
+
+type BuilderOptions struct {
+	// WorkDir is where repositories are cloned into.
+	WorkDir string
+	// ImagePrefix is prepended to built images' name. e.g. "r2d2"
+	ImagePrefix string
+	// Timeout limits the time a single build can take.
+	Timeout time.Duration
+}
+
+// DockerImageBuilder clones the repository and builds image with local docker daemon.
+// Docker CLI (with buildx) and git should be installed.
+type DockerImageBuilder struct {
+	publisher event.Publisher
+	logger    *zap.Logger
+
+	opts BuilderOptions
+
+	mu sync.Mutex
+	// builds holds cancel func of builds in progress by submission ID.
+	builds map[uuid.UUID]context.CancelFunc
+}
+
+var (
+	_ exec_module.ImageBuilder   = (*DockerImageBuilder)(nil)
+	_ exec_module.BuildCanceller = (*DockerImageBuilder)(nil)
+)
+
+func NewDockerImageBuilder(p event.Publisher, logger *zap.Logger, opts BuilderOptions) *DockerImageBuilder {
+	return &DockerImageBuilder{
+		publisher: p,
+		logger:    logger,
+		opts:      opts,
+		builds:    make(map[uuid.UUID]context.CancelFunc),
+	}
+}
+
+// ImageName returns name of the image built for the submission.
+func ImageName(prefix string, submissionID uuid.UUID) string {
+	return fmt.Sprintf("%s/submission:%s", prefix, submissionID)
+}
+
+func (b *DockerImageBuilder) RequestBuild(ctx context.Context, opts exec_module.BuildOpts) error {
+	// Build outlives the request. Result will be informed with event.
+	buildCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), b.opts.Timeout)
+
+	b.mu.Lock()
+	b.builds[opts.ID] = cancel
+	b.mu.Unlock()
+
+	go func() {
+		defer func() {
+			b.mu.Lock()
+			delete(b.builds, opts.ID)
+			b.mu.Unlock()
+
+			cancel()
+		}()
+
+		b.build(buildCtx, opts)
+	}()
+
+	b.logger.Info("requested image build", zap.String("id", opts.ID.String()))
+
+	return nil
+}
+
+func (b *DockerImageBuilder) CancelBuild(ctx context.Context, submissionID uuid.UUID) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if cancel, ok := b.builds[submissionID]; ok {
+		cancel()
+		b.logger.Info("cancelled image build", zap.String("id", submissionID.String()))
+	}
+
+	return nil
+}
+
+func (b *DockerImageBuilder) build(ctx context.Context, opts exec_module.BuildOpts) {
+	start := time.Now()
+
+	log, err := b.cloneAndBuild(ctx, opts)
+
+	e := event.ExecEvent{
+		ID:      opts.ID,
+		Success: err == nil,
+		Took:    time.Since(start),
+		Extra:   tail(redact(log.String(), opts.Token), maxLogLength),
+	}
+
+	if err != nil {
+		b.logger.Info("image build failed", zap.String("id", opts.ID.String()), zap.Error(err))
+	}
+
+	// Result should be informed even if the build is cancelled or timed out.
+	if err := b.publisher.Publish(context.WithoutCancel(ctx), event.TopicBuild, e); err != nil {
+		b.logger.Error("failed to publish build result",
+			zap.String("id", opts.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+func (b *DockerImageBuilder) cloneAndBuild(ctx context.Context, opts exec_module.BuildOpts) (*bytes.Buffer, error) {
+	log := bytes.NewBuffer(nil)
+
+	dir := filepath.Join(b.opts.WorkDir, opts.ID.String())
+	defer os.RemoveAll(dir)
+
+	for _, args := range b.commands(dir, opts) {
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Stdout = log
+		cmd.Stderr = log
+
+		if err := cmd.Run(); err != nil {
+			return log, errors.Wrapf(err, "running %s", args[0])
+		}
+	}
+
+	return log, nil
+}
+
+// commands returns commands to clone the repository into dir and build image from it.
+func (b *DockerImageBuilder) commands(dir string, opts exec_module.BuildOpts) [][]string {
+	return [][]string{
+		{"git", "clone", "--quiet", cloneURL(opts.Repository, opts.Token), dir},
+		{"git", "-C", dir, "checkout", "--quiet", opts.CommitHash},
+		{
+			"docker", "buildx", "build",
+			"--platform", opts.Platform,
+			"--tag", ImageName(b.opts.ImagePrefix, opts.ID),
+			"--load", dir,
+		},
+	}
+}
+
+// cloneURL returns URL of the repository. Token is used to clone private repositories.
+func cloneURL(repository, token string) string {
+	if token == "" {
+		return "https://github.com/" + repository + ".git"
+	}
+	return "https://x-access-token:" + token + "@github.com/" + repository + ".git"
+}
+
+// redact hides secret from s.
+func redact(s, secret string) string {
+	if secret == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, secret, "***")
+}
+
+// tail returns last n bytes of s.
+func tail(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[len(s)-n:]
+}
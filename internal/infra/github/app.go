@@ -0,0 +1,191 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	report_module "github.com/oneee-playground/r2d2-api-server/internal/module/report"
+	submission_module "github.com/oneee-playground/r2d2-api-server/internal/module/submission"
+	user_module "github.com/oneee-playground/r2d2-api-server/internal/module/user"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// AppClient acts as github app, which users install on their repositories.
+type AppClient struct {
+	httpClient *http.Client
+	logger     *zap.Logger
+
+	appID      int64
+	privateKey *rsa.PrivateKey
+}
+
+var (
+	_ user_module.InstallationClient            = (*AppClient)(nil)
+	_ submission_module.InstallationTokenIssuer = (*AppClient)(nil)
+	_ report_module.StatusReporter              = (*AppClient)(nil)
+)
+
+func NewAppClient(client *http.Client, logger *zap.Logger, appID int64, privateKey *rsa.PrivateKey) *AppClient {
+	return &AppClient{
+		httpClient: client,
+		logger:     logger,
+		appID:      appID,
+		privateKey: privateKey,
+	}
+}
+
+type githubInstallationResponse struct {
+	Account struct {
+		Login string `json:"login"`
+	} `json:"account"`
+}
+
+func (c *AppClient) FetchInstallationOwner(ctx context.Context, installationID int64) (string, error) {
+	url := fmt.Sprintf("https://api.github.com/app/installations/%d", installationID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "creating request")
+	}
+
+	if err := c.authenticate(req); err != nil {
+		return "", err
+	}
+
+	var decoded githubInstallationResponse
+	if err := sendRequest(c.httpClient, req, &decoded); err != nil {
+		if isStatus(err, http.StatusNotFound) {
+			return "", user_module.ErrInstallationNotFound
+		}
+		return "", err
+	}
+
+	return decoded.Account.Login, nil
+}
+
+type installationTokenRequest struct {
+	Repositories []string          `json:"repositories"`
+	Permissions  map[string]string `json:"permissions"`
+}
+
+type installationTokenResponse struct {
+	Token string `json:"token"`
+}
+
+func (c *AppClient) IssueInstallationToken(ctx context.Context, installationID int64, fullName string) (string, error) {
+	// Token is only able to read the repository.
+	return c.issueToken(ctx, installationID, fullName,
+		map[string]string{"contents": "read", "metadata": "read"},
+	)
+}
+
+type commitStatusRequest struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description"`
+	Context     string `json:"context"`
+}
+
+func (c *AppClient) ReportStatus(ctx context.Context, installationID int64, fullName, sha string, status report_module.CommitStatus) error {
+	token, err := c.issueToken(ctx, installationID, fullName, map[string]string{"statuses": "write"})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("https://api.github.com/repos/%s/statuses/%s", fullName, sha)
+
+	body, err := json.Marshal(commitStatusRequest{
+		State:       string(status.State),
+		TargetURL:   status.TargetURL,
+		Description: status.Description,
+		Context:     status.Context,
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshalling request body")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	var decoded struct{}
+	if err := sendRequest(c.httpClient, req, &decoded); err != nil {
+		if isStatus(err, http.StatusNotFound) || isStatus(err, http.StatusUnprocessableEntity) {
+			return submission_module.ErrRepositoryNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// issueToken issues installation token scoped to the repository with given permissions.
+func (c *AppClient) issueToken(ctx context.Context, installationID int64, fullName string, permissions map[string]string) (string, error) {
+	url := fmt.Sprintf("https://api.github.com/app/installations/%d/access_tokens", installationID)
+
+	_, name, _ := strings.Cut(fullName, "/")
+
+	body, err := json.Marshal(installationTokenRequest{
+		Repositories: []string{name},
+		Permissions:  permissions,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "marshalling request body")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", errors.Wrap(err, "creating request")
+	}
+
+	if err := c.authenticate(req); err != nil {
+		return "", err
+	}
+
+	var decoded installationTokenResponse
+	if err := sendRequest(c.httpClient, req, &decoded); err != nil {
+		// Github responds with 422 if the installation cannot access the repository.
+		if isStatus(err, http.StatusNotFound) || isStatus(err, http.StatusUnprocessableEntity) {
+			return "", submission_module.ErrRepositoryNotFound
+		}
+		return "", err
+	}
+
+	return decoded.Token, nil
+}
+
+// authenticate signs jwt for the app, which is valid for a few minutes.
+func (c *AppClient) authenticate(req *http.Request) error {
+	if c.privateKey == nil {
+		return errors.New("github app is not configured")
+	}
+
+	now := time.Now()
+
+	// Issued time is set in the past to allow clock drift.
+	claims := jwt.StandardClaims{
+		IssuedAt:  now.Add(-time.Minute).Unix(),
+		ExpiresAt: now.Add(5 * time.Minute).Unix(),
+		Issuer:    strconv.FormatInt(c.appID, 10),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(c.privateKey)
+	if err != nil {
+		return errors.Wrap(err, "signing app token")
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
@@ -0,0 +1,134 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	report_module "github.com/oneee-playground/r2d2-api-server/internal/module/report"
+	submission_module "github.com/oneee-playground/r2d2-api-server/internal/module/submission"
+	user_module "github.com/oneee-playground/r2d2-api-server/internal/module/user"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+func TestAppClientSuite(t *testing.T) {
+	suite.Run(t, new(AppClientSuite))
+}
+
+type AppClientSuite struct {
+	suite.Suite
+
+	mockTransport *httpmock.MockTransport
+
+	client *AppClient
+}
+
+func (s *AppClientSuite) SetupTest() {
+	s.mockTransport = httpmock.NewMockTransport()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+
+	s.client = NewAppClient(&http.Client{Transport: s.mockTransport}, zap.NewNop(), 1, key)
+}
+
+func (s *AppClientSuite) TestFetchInstallationOwner() {
+	defer s.mockTransport.Reset()
+
+	s.mockTransport.RegisterResponder(http.MethodGet,
+		"https://api.github.com/app/installations/1",
+		httpmock.NewStringResponder(http.StatusOK, `{"account": {"login": "user"}}`),
+	)
+	s.mockTransport.RegisterResponder(http.MethodGet,
+		"https://api.github.com/app/installations/2",
+		httpmock.NewStringResponder(http.StatusNotFound, `{"message": "Not Found"}`),
+	)
+
+	owner, err := s.client.FetchInstallationOwner(context.Background(), 1)
+	s.NoError(err)
+	s.Equal("user", owner)
+
+	_, err = s.client.FetchInstallationOwner(context.Background(), 2)
+	s.ErrorIs(err, user_module.ErrInstallationNotFound)
+}
+
+func (s *AppClientSuite) TestIssueInstallationToken() {
+	defer s.mockTransport.Reset()
+
+	s.mockTransport.RegisterResponder(http.MethodPost,
+		"https://api.github.com/app/installations/1/access_tokens",
+		func(r *http.Request) (*http.Response, error) {
+			var body installationTokenRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			// Token should be scoped to the repository.
+			if len(body.Repositories) != 1 || body.Repositories[0] != "repo" {
+				return httpmock.NewStringResponse(http.StatusUnprocessableEntity, `{}`), nil
+			}
+
+			return httpmock.NewStringResponse(http.StatusCreated, `{"token": "token"}`), nil
+		},
+	)
+
+	token, err := s.client.IssueInstallationToken(context.Background(), 1, "user/repo")
+	s.NoError(err)
+	s.Equal("token", token)
+
+	_, err = s.client.IssueInstallationToken(context.Background(), 1, "user/other")
+	s.ErrorIs(err, submission_module.ErrRepositoryNotFound)
+}
+
+func (s *AppClientSuite) TestReportStatus() {
+	defer s.mockTransport.Reset()
+
+	s.mockTransport.RegisterResponder(http.MethodPost,
+		"https://api.github.com/app/installations/1/access_tokens",
+		func(r *http.Request) (*http.Response, error) {
+			var body installationTokenRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			if body.Permissions["statuses"] != "write" {
+				return httpmock.NewStringResponse(http.StatusUnprocessableEntity, `{}`), nil
+			}
+
+			return httpmock.NewStringResponse(http.StatusCreated, `{"token": "token"}`), nil
+		},
+	)
+	s.mockTransport.RegisterResponder(http.MethodPost,
+		"https://api.github.com/repos/user/repo/statuses/0123456789",
+		func(r *http.Request) (*http.Response, error) {
+			if r.Header.Get("Authorization") != "Bearer token" {
+				return httpmock.NewStringResponse(http.StatusNotFound, `{}`), nil
+			}
+
+			var body commitStatusRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			s.Equal("failure", body.State)
+			s.Equal("r2d2", body.Context)
+
+			return httpmock.NewStringResponse(http.StatusCreated, `{"id": 1}`), nil
+		},
+	)
+
+	status := report_module.CommitStatus{
+		State:       report_module.StateFailure,
+		Description: "Build failed",
+		Context:     "r2d2",
+	}
+
+	err := s.client.ReportStatus(context.Background(), 1, "user/repo", "0123456789", status)
+	s.NoError(err)
+	s.Equal(2, s.mockTransport.GetTotalCallCount())
+}
@@ -8,11 +8,13 @@ import (
 
 	"github.com/oneee-playground/r2d2-api-server/internal/domain"
 	auth_module "github.com/oneee-playground/r2d2-api-server/internal/module/auth"
+	submission_module "github.com/oneee-playground/r2d2-api-server/internal/module/submission"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
 // Client is github-specific oauth client.
+// It also looks up repositories for submissions.
 type Client struct {
 	httpClient *http.Client
 	logger     *zap.Logger
@@ -20,7 +22,10 @@ type Client struct {
 	clientID, clientSecret string
 }
 
-var _ auth_module.OAuthClient = (*Client)(nil)
+var (
+	_ auth_module.OAuthClient        = (*Client)(nil)
+	_ submission_module.SourceClient = (*Client)(nil)
+)
 
 func NewClient(client *http.Client, logger *zap.Logger, clientID, clientSecret string) *Client {
 	return &Client{
@@ -94,6 +99,94 @@ func (c *Client) GetUserInfo(ctx context.Context, token string) (domain.User, er
 	return user, nil
 }
 
+type githubRepositoryResponse struct {
+	FullName string `json:"full_name"`
+	Private  bool   `json:"private"`
+	Owner    struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
+
+func (c *Client) FetchRepository(ctx context.Context, fullName, token string) (submission_module.SourceRepository, error) {
+	url := "https://api.github.com/repos/" + fullName
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return submission_module.SourceRepository{}, errors.Wrap(err, "creating request")
+	}
+
+	c.authenticate(req, token)
+
+	var decoded githubRepositoryResponse
+	if err := sendRequest(c.httpClient, req, &decoded); err != nil {
+		if isStatus(err, http.StatusNotFound) {
+			return submission_module.SourceRepository{}, submission_module.ErrRepositoryNotFound
+		}
+		return submission_module.SourceRepository{}, err
+	}
+
+	repository := submission_module.SourceRepository{
+		FullName: decoded.FullName,
+		Owner:    decoded.Owner.Login,
+		Private:  decoded.Private,
+	}
+
+	return repository, nil
+}
+
+type githubCommitResponse struct {
+	SHA string `json:"sha"`
+}
+
+func (c *Client) ResolveCommit(ctx context.Context, fullName, sha, token string) (string, error) {
+	url := "https://api.github.com/repos/" + fullName + "/commits/" + sha
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "creating request")
+	}
+
+	c.authenticate(req, token)
+
+	var decoded githubCommitResponse
+	if err := sendRequest(c.httpClient, req, &decoded); err != nil {
+		// Github responds with 422 if sha is unknown or ambiguous.
+		if isStatus(err, http.StatusNotFound) || isStatus(err, http.StatusUnprocessableEntity) {
+			return "", submission_module.ErrCommitNotFound
+		}
+		return "", err
+	}
+
+	return decoded.SHA, nil
+}
+
+// authenticate authenticates the request with token if given.
+// Otherwise, as oauth app which has higher rate limit than anonymous one.
+func (c *Client) authenticate(req *http.Request, token string) {
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return
+	}
+
+	if c.clientID != "" {
+		req.SetBasicAuth(c.clientID, c.clientSecret)
+	}
+}
+
+// statusCodeError is returned when github responds with unexpected status code.
+type statusCodeError struct {
+	code int
+}
+
+func (e statusCodeError) Error() string {
+	return fmt.Sprintf("status code is not 2xx, given: %d", e.code)
+}
+
+func isStatus(err error, code int) bool {
+	var sErr statusCodeError
+	return errors.As(err, &sErr) && sErr.code == code
+}
+
 // sendRequest sends request via httpClient and decodes response body with specified type T.
 func sendRequest[T any](httpClient *http.Client, req *http.Request, val *T) error {
 	req.Header.Set("Accept", "application/json")
@@ -104,8 +197,8 @@ func sendRequest[T any](httpClient *http.Client, req *http.Request, val *T) erro
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode != http.StatusOK {
-		return errors.Errorf("status code is not 200, given: %d", res.StatusCode)
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return statusCodeError{code: res.StatusCode}
 	}
 
 	if err := json.NewDecoder(res.Body).Decode(val); err != nil {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/jarcoal/httpmock"
 	auth_module "github.com/oneee-playground/r2d2-api-server/internal/module/auth"
+	submission_module "github.com/oneee-playground/r2d2-api-server/internal/module/submission"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
 )
@@ -82,3 +83,51 @@ func (s *GitHubClientSuote) TestIssueAccessToken() {
 		})
 	}
 }
+
+func (s *GitHubClientSuote) TestFetchRepository() {
+	defer s.mockTransport.Reset()
+
+	s.mockTransport.RegisterResponder(http.MethodGet,
+		"https://api.github.com/repos/owner/repo",
+		httpmock.NewStringResponder(http.StatusOK, `
+		{
+			"full_name": "owner/repo",
+			"private": true,
+			"owner": {"login": "owner"}
+		}
+		`),
+	)
+	s.mockTransport.RegisterResponder(http.MethodGet,
+		"https://api.github.com/repos/owner/missing",
+		httpmock.NewStringResponder(http.StatusNotFound, `{"message": "Not Found"}`),
+	)
+
+	repository, err := s.client.FetchRepository(context.Background(), "owner/repo", "")
+	s.NoError(err)
+	s.Equal(submission_module.SourceRepository{FullName: "owner/repo", Owner: "owner", Private: true}, repository)
+
+	_, err = s.client.FetchRepository(context.Background(), "owner/missing", "")
+	s.ErrorIs(err, submission_module.ErrRepositoryNotFound)
+}
+
+func (s *GitHubClientSuote) TestResolveCommit() {
+	defer s.mockTransport.Reset()
+
+	fullHash := "0123456789abcdef0123456789abcdef01234567"
+
+	s.mockTransport.RegisterResponder(http.MethodGet,
+		"https://api.github.com/repos/owner/repo/commits/0123456",
+		httpmock.NewStringResponder(http.StatusOK, `{"sha": "`+fullHash+`"}`),
+	)
+	s.mockTransport.RegisterResponder(http.MethodGet,
+		"https://api.github.com/repos/owner/repo/commits/abcdef",
+		httpmock.NewStringResponder(http.StatusUnprocessableEntity, `{"message": "No commit found for SHA: abcdef"}`),
+	)
+
+	sha, err := s.client.ResolveCommit(context.Background(), "owner/repo", "0123456", "")
+	s.NoError(err)
+	s.Equal(fullHash, sha)
+
+	_, err = s.client.ResolveCommit(context.Background(), "owner/repo", "abcdef", "")
+	s.ErrorIs(err, submission_module.ErrCommitNotFound)
+}
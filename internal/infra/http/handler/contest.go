@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/http/util"
+)
+
+type ContestHandler struct {
+	usecase domain.ContestUsecase
+}
+
+func NewContestHandler(usecase domain.ContestUsecase) *ContestHandler {
+	return &ContestHandler{usecase: usecase}
+}
+
+func (h *ContestHandler) HandleGetList(c *gin.Context) {
+	out, err := h.usecase.GetList(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *ContestHandler) HandleGetContest(c *gin.Context) {
+	var in dto.IDInput
+	if err := c.ShouldBindUri(&in); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	out, err := h.usecase.GetContest(c.Request.Context(), in)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *ContestHandler) HandleCreateContest(c *gin.Context) {
+	var in dto.ContestInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	out, err := h.usecase.CreateContest(c.Request.Context(), in)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, out)
+}
+
+func (h *ContestHandler) HandleUpdateContest(c *gin.Context) {
+	var in dto.UpdateContestInput
+
+	if err := c.ShouldBindUri(&in.IDInput); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	if err := c.ShouldBindJSON(&in.ContestInput); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	if err := h.usecase.UpdateContest(c.Request.Context(), in); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *ContestHandler) HandleGetScoreboard(c *gin.Context) {
+	var in dto.IDInput
+	if err := c.ShouldBindUri(&in); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	out, err := h.usecase.GetScoreboard(c.Request.Context(), in)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/http/util"
+)
+
+type RepositoryLinkHandler struct {
+	usecase domain.RepositoryLinkUsecase
+}
+
+func NewRepositoryLinkHandler(usecase domain.RepositoryLinkUsecase) *RepositoryLinkHandler {
+	return &RepositoryLinkHandler{usecase: usecase}
+}
+
+func (h *RepositoryLinkHandler) HandleGetLink(c *gin.Context) {
+	var in dto.IDInput
+
+	if err := c.ShouldBindUri(&in); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	out, err := h.usecase.GetLink(c.Request.Context(), in)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *RepositoryLinkHandler) HandleSetLink(c *gin.Context) {
+	var in dto.RepositoryLinkInput
+
+	if err := c.ShouldBindUri(&in.IDInput); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	if err := h.usecase.SetLink(c.Request.Context(), in); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *RepositoryLinkHandler) HandleDeleteLink(c *gin.Context) {
+	var in dto.IDInput
+
+	if err := c.ShouldBindUri(&in); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	if err := h.usecase.DeleteLink(c.Request.Context(), in); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
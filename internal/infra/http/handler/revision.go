@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/http/util"
+)
+
+type RevisionHandler struct {
+	usecase domain.RevisionUsecase
+}
+
+func NewRevisionHandler(usecase domain.RevisionUsecase) *RevisionHandler {
+	return &RevisionHandler{usecase: usecase}
+}
+
+func (h *RevisionHandler) HandleGetList(c *gin.Context) {
+	var in dto.IDInput
+
+	if err := c.ShouldBindUri(&in); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	out, err := h.usecase.GetList(c.Request.Context(), in)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *RevisionHandler) HandleDiff(c *gin.Context) {
+	var in dto.RevisionDiffInput
+
+	if err := c.ShouldBindUri(&in.IDInput); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	if err := c.ShouldBindQuery(&in.RevisionPair); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	out, err := h.usecase.Diff(c.Request.Context(), in)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
@@ -39,6 +39,23 @@ func (h *SubmissionHandler) HandleGetList(c *gin.Context) {
 	c.JSON(http.StatusOK, out)
 }
 
+func (h *SubmissionHandler) HandleGetLeaderboard(c *gin.Context) {
+	var in dto.IDInput
+
+	if err := c.ShouldBindUri(&in); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	out, err := h.usecase.GetLeaderboard(c.Request.Context(), in)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
 func (h *SubmissionHandler) HandleSubmit(c *gin.Context) {
 	var in dto.SubmissionInput
 
@@ -98,3 +115,62 @@ func (h *SubmissionHandler) HandleCancel(c *gin.Context) {
 
 	c.Status(http.StatusOK)
 }
+
+func (h *SubmissionHandler) HandleGetPendingList(c *gin.Context) {
+	var in dto.PendingSubmissionListInput
+
+	if err := c.ShouldBindQuery(&in); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	out, err := h.usecase.GetPendingList(c.Request.Context(), in)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *SubmissionHandler) HandleDecideBulk(c *gin.Context) {
+	var in dto.BulkDecisionInput
+
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	out, err := h.usecase.DecideBulk(c.Request.Context(), in)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *SubmissionHandler) HandleRejudge(c *gin.Context) {
+	var in dto.RejudgeInput
+
+	if err := c.ShouldBindUri(&in.IDInput); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	// Filters are optional.
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&in); err != nil {
+			c.Error(util.WrapWithBadRequest(err))
+			return
+		}
+	}
+
+	out, err := h.usecase.Rejudge(c.Request.Context(), in)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, out)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/oneee-playground/r2d2-api-server/internal/domain"
 	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
 	"github.com/oneee-playground/r2d2-api-server/internal/infra/http/util"
@@ -81,6 +82,48 @@ func (h *TaskHandler) HandleUpdateTask(c *gin.Context) {
 	c.Status(http.StatusCreated)
 }
 
+func (h *TaskHandler) HandleSetApprovalPolicy(c *gin.Context) {
+	var in dto.ApprovalPolicyInput
+
+	if err := c.ShouldBindUri(&in.IDInput); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	if err := c.ShouldBindJSON(&in.ApprovalPolicy); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	if err := h.usecase.SetApprovalPolicy(c.Request.Context(), in); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *TaskHandler) HandleSetSubmissionLimit(c *gin.Context) {
+	var in dto.SubmissionLimitInput
+
+	if err := c.ShouldBindUri(&in.IDInput); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	if err := c.ShouldBindJSON(&in.SubmissionLimit); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	if err := h.usecase.SetSubmissionLimit(c.Request.Context(), in); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
 func (h *TaskHandler) HandleChangeStage(c *gin.Context) {
 	var in dto.TaskStageInput
 
@@ -102,3 +145,90 @@ func (h *TaskHandler) HandleChangeStage(c *gin.Context) {
 
 	c.Status(http.StatusOK)
 }
+
+func (h *TaskHandler) HandleGetReadiness(c *gin.Context) {
+	var in dto.IDInput
+	if err := c.ShouldBindUri(&in); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	out, err := h.usecase.GetReadiness(c.Request.Context(), in)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *TaskHandler) HandleExport(c *gin.Context) {
+	var in dto.IDInput
+	if err := c.ShouldBindUri(&in); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	var format dto.ExportFormat
+	if err := c.ShouldBindQuery(&format); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	out, err := h.usecase.Export(c.Request.Context(), in)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if format.Format == "yaml" {
+		c.YAML(http.StatusOK, out)
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *TaskHandler) HandleImport(c *gin.Context) {
+	var in dto.TaskBundle
+
+	// Bundle can be either json or yaml, depending on content type.
+	b := binding.Default(c.Request.Method, c.ContentType())
+	if err := c.ShouldBindWith(&in, b); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	out, err := h.usecase.Import(c.Request.Context(), in)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, out)
+}
+
+func (h *TaskHandler) HandleClone(c *gin.Context) {
+	var in dto.CloneTaskInput
+
+	if err := c.ShouldBindUri(&in.IDInput); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	// Body is optional.
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&in); err != nil {
+			c.Error(util.WrapWithBadRequest(err))
+			return
+		}
+	}
+
+	out, err := h.usecase.Clone(c.Request.Context(), in)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, out)
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/http/util"
 )
 
 type UserHandler struct {
@@ -24,3 +26,39 @@ func (h *UserHandler) HandleSelfInfo(c *gin.Context) {
 
 	c.JSON(http.StatusOK, out)
 }
+
+func (h *UserHandler) HandleSetLimitExemption(c *gin.Context) {
+	var in dto.LimitExemptionInput
+
+	if err := c.ShouldBindUri(&in.IDInput); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	if err := h.usecase.SetLimitExemption(c.Request.Context(), in); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *UserHandler) HandleSetInstallation(c *gin.Context) {
+	var in dto.InstallationInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	if err := h.usecase.SetInstallation(c.Request.Context(), in); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/http/util"
+)
+
+type WebhookHandler struct {
+	usecase domain.WebhookUsecase
+}
+
+func NewWebhookHandler(usecase domain.WebhookUsecase) *WebhookHandler {
+	return &WebhookHandler{usecase: usecase}
+}
+
+func (h *WebhookHandler) HandleGitHub(c *gin.Context) {
+	// Raw body is needed to verify the signature.
+	payload, err := c.GetRawData()
+	if err != nil {
+		c.Error(util.WrapWithBadRequest(err))
+		return
+	}
+
+	in := dto.GitHubWebhookInput{
+		Event:      c.GetHeader("X-GitHub-Event"),
+		DeliveryID: c.GetHeader("X-GitHub-Delivery"),
+		Signature:  c.GetHeader("X-Hub-Signature-256"),
+		Payload:    payload,
+	}
+
+	if err := h.usecase.ReceiveGitHub(c.Request.Context(), in); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
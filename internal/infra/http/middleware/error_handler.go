@@ -36,11 +36,18 @@ func (h *ErrorHandler) CatchWithStatusCode(c *gin.Context) {
 			sErr = errInternalError
 		}
 
-		c.AbortWithStatusJSON(sErr.StatusCode,
-			gin.H{
-				"message": sErr.Message,
-			},
-		)
+		for key, value := range sErr.Headers {
+			c.Header(key, value)
+		}
+
+		body := gin.H{
+			"message": sErr.Message,
+		}
+		if sErr.Details != nil {
+			body["details"] = sErr.Details
+		}
+
+		c.AbortWithStatusJSON(sErr.StatusCode, body)
 		return
 	}
 
@@ -21,13 +21,18 @@ type Router struct {
 	RequestLogger *zap.Logger
 	ErrorLogger   *zap.Logger
 
+	ContestHandler    *handler.ContestHandler
 	EventHandler      *handler.EventHandler
+	LinkHandler       *handler.RepositoryLinkHandler
 	ResourceHandler   *handler.ResourceHandler
+	ResultHandler     *handler.ResultHandler
+	RevisionHandler   *handler.RevisionHandler
 	SectionHandler    *handler.SectionHandler
 	SubmissionHandler *handler.SubmissionHandler
 	TaskHandler       *handler.TaskHandler
 	UserHandler       *handler.UserHandler
 	AuthHandler       *handler.AuthHandler
+	WebhookHandler    *handler.WebhookHandler
 }
 
 func (r *Router) Build() {
@@ -64,21 +69,58 @@ func (r *Router) Build() {
 		auth.POST("/oauth/github", r.AuthHandler.HandleSignIn)
 	}
 
+	admin := router.Group("/admin", authRequired, adminOnly)
+	{
+		admin.GET("/submissions/pending", r.SubmissionHandler.HandleGetPendingList)
+		admin.PATCH("/submissions/pending", r.SubmissionHandler.HandleDecideBulk)
+	}
+
+	webhook := router.Group("/webhooks")
+	{
+		webhook.POST("/github", r.WebhookHandler.HandleGitHub)
+	}
+
 	user := router.Group("/users")
 	{
 		user.GET("/me", authRequired, memberOnly, r.UserHandler.HandleSelfInfo)
+		user.PUT("/me/installation", authRequired, memberOnly, r.UserHandler.HandleSetInstallation)
+		user.PUT("/:id/limit-exemption", authRequired, adminOnly, r.UserHandler.HandleSetLimitExemption)
 	}
 
 	task := router.Group("/tasks")
 	{
 		task.GET("", r.TaskHandler.HandleGetList)
 		task.POST("", authRequired, adminOnly, r.TaskHandler.HandleCreateTask)
+		task.POST("/import", authRequired, adminOnly, r.TaskHandler.HandleImport)
 
 		oneTask := task.Group("/:id")
 		{
 			oneTask.GET("", r.TaskHandler.HandleGetTask)
 			oneTask.PUT("", authRequired, adminOnly, r.TaskHandler.HandleUpdateTask)
 			oneTask.PATCH("", authRequired, adminOnly, r.TaskHandler.HandleChangeStage)
+			oneTask.GET("/leaderboard", r.SubmissionHandler.HandleGetLeaderboard)
+			oneTask.GET("/readiness", authRequired, adminOnly, r.TaskHandler.HandleGetReadiness)
+			oneTask.GET("/export", authRequired, adminOnly, r.TaskHandler.HandleExport)
+			oneTask.POST("/clone", authRequired, adminOnly, r.TaskHandler.HandleClone)
+			oneTask.PUT("/approval-policy", authRequired, adminOnly, r.TaskHandler.HandleSetApprovalPolicy)
+			oneTask.PUT("/submission-limit", authRequired, adminOnly, r.TaskHandler.HandleSetSubmissionLimit)
+			oneTask.POST("/rejudge", authRequired, adminOnly, r.SubmissionHandler.HandleRejudge)
+			oneTask.GET("/link", authRequired, memberOnly, r.LinkHandler.HandleGetLink)
+			oneTask.PUT("/link", authRequired, memberOnly, r.LinkHandler.HandleSetLink)
+			oneTask.DELETE("/link", authRequired, memberOnly, r.LinkHandler.HandleDeleteLink)
+		}
+	}
+
+	contest := router.Group("/contests")
+	{
+		contest.GET("", r.ContestHandler.HandleGetList)
+		contest.POST("", authRequired, adminOnly, r.ContestHandler.HandleCreateContest)
+
+		oneContest := contest.Group("/:id")
+		{
+			oneContest.GET("", r.ContestHandler.HandleGetContest)
+			oneContest.PUT("", authRequired, adminOnly, r.ContestHandler.HandleUpdateContest)
+			oneContest.GET("/scoreboard", r.ContestHandler.HandleGetScoreboard)
 		}
 	}
 
@@ -101,6 +143,12 @@ func (r *Router) Build() {
 		resource.DELETE("/:name", authRequired, adminOnly, r.ResourceHandler.HandleDeleteResource)
 	}
 
+	revision := router.Group("/tasks/:id/revisions")
+	{
+		revision.GET("", authRequired, adminOnly, r.RevisionHandler.HandleGetList)
+		revision.GET("/diff", authRequired, adminOnly, r.RevisionHandler.HandleDiff)
+	}
+
 	submission := router.Group("/tasks/:id/submissions")
 	{
 		submission.GET("", r.SubmissionHandler.HandleGetList)
@@ -112,6 +160,7 @@ func (r *Router) Build() {
 		oneSubmission.PATCH("", authRequired, adminOnly, r.SubmissionHandler.HandleDecideApproval)
 		oneSubmission.DELETE("", authRequired, memberOnly, r.SubmissionHandler.HandleCancel)
 		oneSubmission.GET("/events", r.EventHandler.HandleGetAll)
+		oneSubmission.GET("/results", r.ResultHandler.HandleGetAll)
 	}
 }
 
@@ -0,0 +1,139 @@
+package inmem
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type EventBusOptions struct {
+	// Workers is the number of goroutines handling messages per topic.
+	Workers int
+	// BufferSize is the capacity of each topic's channel.
+	// Publish blocks when the buffer is full.
+	BufferSize int
+}
+
+// InMemoryEventBus is channel-based event bus.
+// It is meant for local runs and tests, since messages are lost on shutdown.
+type InMemoryEventBus struct {
+	logger *zap.Logger
+	opts   EventBusOptions
+
+	// topics maps topic into its message channel.
+	topics map[event.Topic]chan []byte
+
+	mu       sync.RWMutex
+	handlers map[event.Topic][]event.HandlerFunc
+}
+
+var (
+	_ event.Subscriber = (*InMemoryEventBus)(nil)
+	_ event.Publisher  = (*InMemoryEventBus)(nil)
+)
+
+var ErrUnknownTopic = errors.New("unknown topic")
+
+func NewInMemoryEventBus(logger *zap.Logger, opts EventBusOptions, topics ...event.Topic) *InMemoryEventBus {
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+
+	b := &InMemoryEventBus{
+		logger:   logger,
+		opts:     opts,
+		topics:   make(map[event.Topic]chan []byte, len(topics)),
+		handlers: make(map[event.Topic][]event.HandlerFunc),
+	}
+
+	for _, topic := range topics {
+		b.topics[topic] = make(chan []byte, opts.BufferSize)
+	}
+
+	return b
+}
+
+func (b *InMemoryEventBus) Publish(ctx context.Context, topic event.Topic, e any) error {
+	ch, ok := b.topics[topic]
+	if !ok {
+		return errors.Wrap(ErrUnknownTopic, string(topic))
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "marshalling payload")
+	}
+
+	select {
+	case ch <- payload:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "sending message")
+	}
+}
+
+func (b *InMemoryEventBus) Subscribe(ctx context.Context, topic event.Topic, handlers ...event.HandlerFunc) error {
+	if _, ok := b.topics[topic]; !ok {
+		return errors.Wrap(ErrUnknownTopic, string(topic))
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[topic] = append(b.handlers[topic], handlers...)
+	return nil
+}
+
+// Listen starts workers for all topics and handles published messages.
+// It is required to call it within seperate goroutine since it blocks the flow.
+func (b *InMemoryEventBus) Listen(ctx context.Context) {
+	b.logger.Info("started listening topics")
+
+	var wg sync.WaitGroup
+
+	for topic, ch := range b.topics {
+		for i := 0; i < b.opts.Workers; i++ {
+			wg.Add(1)
+			go b.work(ctx, &wg, topic, ch)
+		}
+	}
+
+	wg.Wait()
+}
+
+func (b *InMemoryEventBus) work(ctx context.Context, wg *sync.WaitGroup, topic event.Topic, ch <-chan []byte) {
+	defer wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case payload := <-ch:
+			b.handle(ctx, topic, payload)
+		}
+	}
+}
+
+func (b *InMemoryEventBus) handle(ctx context.Context, topic event.Topic, payload []byte) {
+	b.mu.RLock()
+	handlers := b.handlers[topic]
+	b.mu.RUnlock()
+
+	for _, f := range handlers {
+		err := f(ctx, topic, payload)
+		if err == event.NoErrSkipHandler {
+			continue
+		}
+
+		if err != nil {
+			b.logger.Error("failed to handle message",
+				zap.String("topic", string(topic)),
+				zap.Error(err),
+			)
+		}
+	}
+}
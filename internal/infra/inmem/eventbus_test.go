@@ -0,0 +1,92 @@
+package inmem
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+func TestInMemoryEventBusSuite(t *testing.T) {
+	suite.Run(t, new(InMemoryEventBusSuite))
+}
+
+type InMemoryEventBusSuite struct {
+	suite.Suite
+
+	bus *InMemoryEventBus
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (s *InMemoryEventBusSuite) SetupTest() {
+	s.bus = NewInMemoryEventBus(zap.NewNop(), EventBusOptions{Workers: 2, BufferSize: 10}, event.TopicSubmission)
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+}
+
+func (s *InMemoryEventBusSuite) TearDownTest() {
+	s.cancel()
+}
+
+func (s *InMemoryEventBusSuite) TestPublishAndHandle() {
+	var (
+		mu       sync.Mutex
+		received []string
+		done     = make(chan struct{}, 2)
+	)
+
+	skip := func(ctx context.Context, topic event.Topic, payload []byte) error {
+		done <- struct{}{}
+		return event.NoErrSkipHandler
+	}
+
+	record := func(ctx context.Context, topic event.Topic, payload []byte) error {
+		var v string
+		s.Require().NoError(json.Unmarshal(payload, &v))
+
+		mu.Lock()
+		received = append(received, v)
+		mu.Unlock()
+
+		done <- struct{}{}
+		return nil
+	}
+
+	s.Require().NoError(s.bus.Subscribe(s.ctx, event.TopicSubmission, skip, record))
+
+	go s.bus.Listen(s.ctx)
+
+	s.Require().NoError(s.bus.Publish(s.ctx, event.TopicSubmission, "hello"))
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			s.FailNow("handler was not called")
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	s.Equal([]string{"hello"}, received)
+}
+
+func (s *InMemoryEventBusSuite) TestUnknownTopic() {
+	s.ErrorIs(s.bus.Publish(s.ctx, event.TopicBuild, "hello"), ErrUnknownTopic)
+	s.ErrorIs(s.bus.Subscribe(s.ctx, event.TopicBuild), ErrUnknownTopic)
+}
+
+func (s *InMemoryEventBusSuite) TestPublishBlocksUntilContextDone() {
+	bus := NewInMemoryEventBus(zap.NewNop(), EventBusOptions{BufferSize: 0}, event.TopicSubmission)
+
+	ctx, cancel := context.WithTimeout(s.ctx, 10*time.Millisecond)
+	defer cancel()
+
+	s.ErrorIs(bus.Publish(ctx, event.TopicSubmission, "hello"), context.DeadlineExceeded)
+}
@@ -0,0 +1,88 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/tx"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const _relayLockKey = "outbox-relay"
+
+type RelayOptions struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// Relay forwards committed outbox messages to the actual event bus.
+// Messages are delivered at least once, so handlers should be idempotent.
+type Relay struct {
+	storage   event.OutboxStorage
+	publisher event.Publisher
+	lock      tx.Locker
+	logger    *zap.Logger
+
+	opts RelayOptions
+}
+
+func NewRelay(s event.OutboxStorage, p event.Publisher, l tx.Locker, logger *zap.Logger, opts RelayOptions) *Relay {
+	return &Relay{
+		storage:   s,
+		publisher: p,
+		lock:      l,
+		logger:    logger,
+		opts:      opts,
+	}
+}
+
+// Run periodically relays undelivered messages.
+// It is required to call it within seperate goroutine since it blocks the flow.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.relay(ctx); err != nil {
+				r.logger.Error("failed to relay outbox messages", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (r *Relay) relay(ctx context.Context) error {
+	// Only one instance should relay at a time, or messages will be sent twice.
+	ctx, release, err := r.lock.AcquireKey(ctx, _relayLockKey)
+	if err != nil {
+		return errors.Wrap(err, "acquiring lock")
+	}
+	defer release()
+
+	messages, err := r.storage.FetchUndelivered(ctx, r.opts.BatchSize)
+	if err != nil {
+		return errors.Wrap(err, "fetching undelivered messages")
+	}
+
+	for _, message := range messages {
+		// Payload is already marshalled. Keep publishers from marshalling it again.
+		payload := json.RawMessage(message.Payload)
+
+		if err := r.publisher.Publish(ctx, message.Topic, payload); err != nil {
+			// Keep order of messages. Try again on next tick.
+			return errors.Wrap(err, "publishing message")
+		}
+
+		if err := r.storage.MarkDelivered(ctx, message.ID); err != nil {
+			return errors.Wrap(err, "marking message delivered")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	"github.com/oneee-playground/r2d2-api-server/test/mocks"
+	"github.com/oneee-playground/r2d2-api-server/test/stubs"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+func TestRelaySuite(t *testing.T) {
+	suite.Run(t, new(RelaySuite))
+}
+
+type RelaySuite struct {
+	suite.Suite
+
+	relay *Relay
+
+	ctl  *gomock.Controller
+	mock struct {
+		storage   *mocks.MockOutboxStorage
+		publisher *mocks.MockPublisher
+	}
+}
+
+func (s *RelaySuite) SetupTest() {
+	s.ctl = gomock.NewController(s.T())
+	s.mock.storage = mocks.NewMockOutboxStorage(s.ctl)
+	s.mock.publisher = mocks.NewMockPublisher(s.ctl)
+
+	s.relay = NewRelay(s.mock.storage, s.mock.publisher, stubs.NewStubLocker(), zap.NewNop(),
+		RelayOptions{Interval: time.Second, BatchSize: 10},
+	)
+}
+
+func (s *RelaySuite) TestRelay() {
+	messages := []event.OutboxMessage{
+		{ID: uuid.New(), Topic: event.TopicSubmission, Payload: []byte(`{"kind":"APPROVE"}`)},
+		{ID: uuid.New(), Topic: event.TopicSubmission, Payload: []byte(`{"kind":"CANCEL"}`)},
+	}
+
+	testcases := []struct {
+		desc    string
+		setup   func()
+		wantErr bool
+	}{
+		{
+			desc: "success",
+			setup: func() {
+				s.mock.storage.EXPECT().
+					FetchUndelivered(gomock.Any(), 10).Return(messages, nil)
+				for _, message := range messages {
+					s.mock.publisher.EXPECT().
+						Publish(gomock.Any(), message.Topic, json.RawMessage(message.Payload)).Return(nil)
+					s.mock.storage.EXPECT().
+						MarkDelivered(gomock.Any(), message.ID).Return(nil)
+				}
+			},
+			wantErr: false,
+		},
+		{
+			desc: "stops on publish failure",
+			setup: func() {
+				s.mock.storage.EXPECT().
+					FetchUndelivered(gomock.Any(), 10).Return(messages, nil)
+				s.mock.publisher.EXPECT().
+					Publish(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("unavailable"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			tc.setup()
+
+			err := s.relay.relay(context.Background())
+			if tc.wantErr {
+				s.Error(err)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
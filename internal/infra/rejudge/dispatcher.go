@@ -0,0 +1,107 @@
+package rejudge
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/tx"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const _dispatcherLockKey = "rejudge-dispatcher"
+
+type DispatcherOptions struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// Dispatcher approves queued re-runs by batch, so that they go through build and test again.
+// Re-runs are throttled to keep job queue from being flooded.
+type Dispatcher struct {
+	submissionRepository domain.SubmissionRepository
+	eventPublisher       event.Publisher
+	lock                 tx.Locker
+	logger               *zap.Logger
+
+	opts DispatcherOptions
+}
+
+func NewDispatcher(sr domain.SubmissionRepository, ep event.Publisher, l tx.Locker, logger *zap.Logger, opts DispatcherOptions) *Dispatcher {
+	return &Dispatcher{
+		submissionRepository: sr,
+		eventPublisher:       ep,
+		lock:                 l,
+		logger:               logger,
+		opts:                 opts,
+	}
+}
+
+// Run periodically dispatches queued re-runs.
+// It is required to call it within seperate goroutine since it blocks the flow.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.dispatch(ctx); err != nil {
+				d.logger.Error("failed to dispatch re-runs", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context) (err error) {
+	ctx, err = tx.NewAtomic(ctx, tx.AtomicOpts{
+		ReadOnly: false,
+		DataSources: []any{
+			d.submissionRepository,
+			d.eventPublisher,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "starting atomic transaction")
+	}
+	defer tx.Evaluate(ctx, &err)
+
+	// Only one instance should dispatch at a time, or re-runs will be started twice.
+	ctx, release, err := d.lock.AcquireKey(ctx, _dispatcherLockKey)
+	if err != nil {
+		return errors.Wrap(err, "acquiring lock")
+	}
+	defer release()
+
+	submissions, err := d.submissionRepository.FetchQueuedRejudges(ctx, d.opts.BatchSize)
+	if err != nil {
+		return errors.Wrap(err, "fetching queued re-runs")
+	}
+
+	for _, submission := range submissions {
+		submission.IsDone = true
+		if err := d.submissionRepository.Update(ctx, submission); err != nil {
+			return errors.Wrap(err, "updating submission")
+		}
+
+		e := event.SubmissionEvent{
+			ID:           uuid.New(),
+			Timestamp:    time.Now(),
+			Kind:         domain.KindApprove,
+			Extra:        "rejudge",
+			SubmissionID: submission.ID,
+			UserID:       submission.UserID,
+		}
+
+		if err := d.eventPublisher.Publish(ctx, event.TopicSubmission, e); err != nil {
+			return errors.Wrap(err, "publishing event")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package rejudge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	"github.com/oneee-playground/r2d2-api-server/test/mocks"
+	"github.com/oneee-playground/r2d2-api-server/test/stubs"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+func TestDispatcherSuite(t *testing.T) {
+	suite.Run(t, new(DispatcherSuite))
+}
+
+type DispatcherSuite struct {
+	suite.Suite
+
+	dispatcher *Dispatcher
+
+	ctl  *gomock.Controller
+	mock struct {
+		submissionRepository *mocks.MockSubmissionRepository
+		eventPublisher       *mocks.MockPublisher
+	}
+}
+
+func (s *DispatcherSuite) SetupTest() {
+	s.ctl = gomock.NewController(s.T())
+	s.mock.submissionRepository = mocks.NewMockSubmissionRepository(s.ctl)
+	s.mock.eventPublisher = mocks.NewMockPublisher(s.ctl)
+
+	s.dispatcher = NewDispatcher(s.mock.submissionRepository, s.mock.eventPublisher, stubs.NewStubLocker(), zap.NewNop(),
+		DispatcherOptions{Interval: time.Second, BatchSize: 2},
+	)
+}
+
+func (s *DispatcherSuite) TestDispatch() {
+	originalID := uuid.New()
+	queued := []domain.Submission{
+		{ID: uuid.New(), UserID: uuid.New(), RejudgeOf: &originalID},
+		{ID: uuid.New(), UserID: uuid.New(), RejudgeOf: &originalID},
+	}
+
+	s.mock.submissionRepository.EXPECT().
+		FetchQueuedRejudges(gomock.Any(), 2).Return(queued, nil)
+
+	for _, submission := range queued {
+		s.mock.submissionRepository.EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			Do(func(_ context.Context, updated domain.Submission) {
+				s.Equal(submission.ID, updated.ID)
+				s.True(updated.IsDone)
+			}).
+			Return(nil)
+		s.mock.eventPublisher.EXPECT().
+			Publish(gomock.Any(), event.TopicSubmission, gomock.Any()).
+			Do(func(_ context.Context, _ event.Topic, e any) {
+				ev := e.(event.SubmissionEvent)
+				s.Equal(submission.ID, ev.SubmissionID)
+				s.Equal(domain.KindApprove, ev.Kind)
+			}).
+			Return(nil)
+	}
+
+	s.NoError(s.dispatcher.dispatch(context.Background()))
+}
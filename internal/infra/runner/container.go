@@ -0,0 +1,136 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oneee-playground/r2d2-api-server/internal/infra/docker"
+	exec_module "github.com/oneee-playground/r2d2-api-server/internal/module/exec"
+	"github.com/pkg/errors"
+)
+
+// environment is set of containers running resources of a job.
+type environment struct {
+	network    string
+	containers []string
+
+	// primaryAddr is host address the primary resource is reachable at.
+	primaryAddr string
+}
+
+// startEnvironment runs all resources of the job within one docker network.
+// Primary resource runs the image built from the submission.
+// environment should be torn down even if it returns an error.
+func startEnvironment(ctx context.Context, imagePrefix string, job *exec_module.Job) (*environment, error) {
+	env := &environment{network: "r2d2-" + job.Submission.ID.String()}
+
+	if _, err := runDocker(ctx, "network", "create", env.network); err != nil {
+		return env, errors.Wrap(err, "creating network")
+	}
+
+	var primary *exec_module.Resource
+
+	for idx, resource := range job.Resources {
+		image := resource.Image
+		if resource.IsPrimary {
+			image = docker.ImageName(imagePrefix, job.Submission.ID)
+			primary = &job.Resources[idx]
+		}
+
+		container := env.network + "-" + resource.Name
+
+		args := []string{
+			"run", "--detach",
+			"--name", container,
+			"--network", env.network,
+			"--network-alias", resource.Name,
+			"--cpus", strconv.FormatFloat(resource.CPU, 'f', -1, 64),
+			"--memory", strconv.FormatUint(resource.Memory, 10) + "m",
+		}
+		if resource.IsPrimary {
+			// Publish to random port of host.
+			args = append(args, "--publish", "127.0.0.1::"+strconv.Itoa(int(resource.Port)))
+		}
+		args = append(args, image)
+
+		if _, err := runDocker(ctx, args...); err != nil {
+			return env, errors.Wrapf(err, "running resource %s", resource.Name)
+		}
+
+		env.containers = append(env.containers, container)
+	}
+
+	if primary == nil {
+		return env, errors.New("no primary resource")
+	}
+
+	out, err := runDocker(ctx, "port", env.network+"-"+primary.Name, strconv.Itoa(int(primary.Port))+"/tcp")
+	if err != nil {
+		return env, errors.Wrap(err, "finding published port")
+	}
+
+	// Output may contain both ipv4 and ipv6 bindings. Use the first one.
+	env.primaryAddr, _, _ = strings.Cut(strings.TrimSpace(out), "\n")
+
+	return env, nil
+}
+
+// waitReady waits until primary resource accepts tcp connections.
+func (env *environment) waitReady(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+
+	for {
+		conn, err := dialer.DialContext(ctx, "tcp", env.primaryAddr)
+		if err == nil {
+			return conn.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(err, "primary resource is not ready")
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// teardown removes all containers and network.
+// It uses its own context since ctx of the job might be done.
+func (env *environment) teardown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if len(env.containers) > 0 {
+		args := append([]string{"rm", "--force"}, env.containers...)
+		if _, err := runDocker(ctx, args...); err != nil {
+			return errors.Wrap(err, "removing containers")
+		}
+	}
+
+	if _, err := runDocker(ctx, "network", "rm", env.network); err != nil {
+		return errors.Wrap(err, "removing network")
+	}
+
+	return nil
+}
+
+func runDocker(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrap(err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,248 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	exec_module "github.com/oneee-playground/r2d2-api-server/internal/module/exec"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type RunnerOptions struct {
+	// Workers is the number of jobs run concurrently.
+	Workers int
+	// QueueSize is the number of jobs that can wait to be run.
+	QueueSize int
+
+	// ImagePrefix should be same as the one used by image builder.
+	ImagePrefix string
+
+	StartTimeout   time.Duration
+	RequestTimeout time.Duration
+	// LoadDuration is how long each load section lasts.
+	LoadDuration time.Duration
+	// MaxFailureRatio is the ratio of failed requests allowed in load section.
+	MaxFailureRatio float64
+}
+
+// LocalJobRunner runs jobs with local docker daemon, and informs results with eventbus.
+type LocalJobRunner struct {
+	publisher  event.Publisher
+	logger     *zap.Logger
+	httpClient *http.Client
+
+	opts RunnerOptions
+	jobs chan *exec_module.Job
+
+	mu sync.Mutex
+	// states holds jobs appended but not finished by submission ID.
+	// Cancel func is nil while the job is waiting to be run.
+	states map[uuid.UUID]context.CancelFunc
+}
+
+var (
+	_ exec_module.JobQueue     = (*LocalJobRunner)(nil)
+	_ exec_module.JobCanceller = (*LocalJobRunner)(nil)
+)
+
+func NewLocalJobRunner(p event.Publisher, logger *zap.Logger, opts RunnerOptions) *LocalJobRunner {
+	return &LocalJobRunner{
+		publisher:  p,
+		logger:     logger,
+		httpClient: &http.Client{Timeout: opts.RequestTimeout},
+		opts:       opts,
+		jobs:       make(chan *exec_module.Job, opts.QueueSize),
+		states:     make(map[uuid.UUID]context.CancelFunc),
+	}
+}
+
+func (r *LocalJobRunner) Append(ctx context.Context, job *exec_module.Job) error {
+	r.mu.Lock()
+	r.states[job.Submission.ID] = nil
+	r.mu.Unlock()
+
+	select {
+	case r.jobs <- job:
+	case <-ctx.Done():
+		r.mu.Lock()
+		delete(r.states, job.Submission.ID)
+		r.mu.Unlock()
+
+		return errors.Wrap(ctx.Err(), "appending job")
+	}
+
+	r.logger.Info("job enqueued",
+		zap.String("taskID", job.TaskID.String()),
+		zap.String("submissionID", job.Submission.ID.String()),
+	)
+
+	return nil
+}
+
+// CancelJob drops the job if it is waiting, or stops it if it is running.
+func (r *LocalJobRunner) CancelJob(ctx context.Context, submissionID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cancel, ok := r.states[submissionID]
+	if !ok {
+		return nil
+	}
+
+	if cancel != nil {
+		cancel()
+	}
+	delete(r.states, submissionID)
+
+	r.logger.Info("job cancelled", zap.String("submissionID", submissionID.String()))
+
+	return nil
+}
+
+// Run starts workers running appended jobs.
+// It is required to call it within seperate goroutine since it blocks the flow.
+func (r *LocalJobRunner) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < max(r.opts.Workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-r.jobs:
+					r.run(ctx, job)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (r *LocalJobRunner) run(ctx context.Context, job *exec_module.Job) {
+	id := job.Submission.ID
+
+	r.mu.Lock()
+	if _, ok := r.states[id]; !ok {
+		// Cancelled while waiting.
+		r.mu.Unlock()
+		return
+	}
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	r.states[id] = cancel
+	r.mu.Unlock()
+
+	defer func() {
+		r.mu.Lock()
+		delete(r.states, id)
+		r.mu.Unlock()
+
+		cancel()
+	}()
+
+	start := time.Now()
+
+	results, err := r.runJob(jobCtx, job)
+
+	e := event.ExecEvent{
+		ID:      job.Submission.ID,
+		Success: err == nil,
+		Took:    time.Since(start),
+		Extra:   fmt.Sprintf("all %d sections passed", len(job.Sections)),
+		Results: results,
+	}
+
+	if err != nil {
+		e.Extra = err.Error()
+	}
+
+	if err := r.publisher.Publish(ctx, event.TopicTest, e); err != nil {
+		r.logger.Error("failed to publish test result",
+			zap.String("submissionID", job.Submission.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+// runJob runs all sections of the job in index order.
+// It stops at the first failing section, and returns results of the sections ran.
+func (r *LocalJobRunner) runJob(ctx context.Context, job *exec_module.Job) ([]event.SectionResult, error) {
+	env, err := startEnvironment(ctx, r.opts.ImagePrefix, job)
+	defer func() {
+		if err := env.teardown(); err != nil {
+			r.logger.Error("failed to tear down environment",
+				zap.String("submissionID", job.Submission.ID.String()),
+				zap.Error(err),
+			)
+		}
+	}()
+	if err != nil {
+		return nil, errors.Wrap(err, "starting environment")
+	}
+
+	if err := env.waitReady(ctx, r.opts.StartTimeout); err != nil {
+		return nil, err
+	}
+
+	baseURL := "http://" + env.primaryAddr
+
+	results := make([]event.SectionResult, 0, len(job.Sections))
+
+	for idx, section := range job.Sections {
+		result, err := r.runSection(ctx, baseURL, section)
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+
+		if err != nil {
+			return results, errors.Wrapf(err, "section %d (%s) failed", idx, section.Type)
+		}
+	}
+
+	return results, nil
+}
+
+func (r *LocalJobRunner) runSection(ctx context.Context, baseURL string, section exec_module.Section) (event.SectionResult, error) {
+	sectionResult := event.SectionResult{SectionID: section.ID}
+
+	ex, err := parseExample(section.Example)
+	if err != nil {
+		return sectionResult, err
+	}
+
+	var result loadResult
+
+	switch section.Type {
+	case domain.TypeScenario:
+		result, err = probe(ctx, r.httpClient, baseURL, ex)
+	case domain.TypeLoad:
+		result, err = load(ctx, r.httpClient, baseURL, ex, section.RPM, r.opts.LoadDuration)
+		if err == nil && float64(result.Failed) > float64(result.Total)*r.opts.MaxFailureRatio {
+			err = errors.New(result.String())
+		}
+	default:
+		err = errors.Errorf("unknown section type: %s", section.Type)
+	}
+
+	sectionResult.Passed = err == nil
+	sectionResult.TotalRequests = result.Total
+	sectionResult.FailedRequests = result.Failed
+	sectionResult.P50 = result.percentile(50)
+	sectionResult.P95 = result.percentile(95)
+	sectionResult.P99 = result.percentile(99)
+
+	return sectionResult, err
+}
@@ -0,0 +1,195 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// example is the format of section's example.
+type example struct {
+	Request struct {
+		Method  string            `json:"method"`
+		Path    string            `json:"path"`
+		Headers map[string]string `json:"headers"`
+		Body    json.RawMessage   `json:"body"`
+	} `json:"request"`
+	Response struct {
+		Status int             `json:"status"`
+		Body   json.RawMessage `json:"body"`
+	} `json:"response"`
+}
+
+// parseExample parses raw example. Missing fields are filled with defaults,
+// which is "GET /" expecting status 200.
+func parseExample(raw string) (example, error) {
+	var ex example
+	if raw != "" {
+		if err := json.Unmarshal([]byte(raw), &ex); err != nil {
+			return example{}, errors.Wrap(err, "parsing example")
+		}
+	}
+
+	if ex.Request.Method == "" {
+		ex.Request.Method = http.MethodGet
+	}
+	if ex.Request.Path == "" {
+		ex.Request.Path = "/"
+	}
+	if ex.Response.Status == 0 {
+		ex.Response.Status = http.StatusOK
+	}
+
+	return ex, nil
+}
+
+// check sends the example request to baseURL and compares response with expected one.
+func check(ctx context.Context, client *http.Client, baseURL string, ex example) error {
+	var body io.Reader
+	if len(ex.Request.Body) > 0 {
+		body = bytes.NewReader(ex.Request.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, ex.Request.Method, baseURL+ex.Request.Path, body)
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+
+	for k, v := range ex.Request.Headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "performing request")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != ex.Response.Status {
+		return errors.Errorf("expected status %d, given: %d", ex.Response.Status, res.StatusCode)
+	}
+
+	if len(ex.Response.Body) == 0 {
+		return nil
+	}
+
+	var expected, actual any
+	if err := json.Unmarshal(ex.Response.Body, &expected); err != nil {
+		return errors.Wrap(err, "decoding expected body")
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&actual); err != nil {
+		return errors.Wrap(err, "decoding response body")
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		return errors.New("response body does not match")
+	}
+
+	return nil
+}
+
+// loadResult is the result of sending requests.
+type loadResult struct {
+	Total  int
+	Failed int
+
+	// Latencies holds elapsed time of every request, including failed ones.
+	Latencies []time.Duration
+}
+
+// record adds the outcome of a request into the result.
+func (r *loadResult) record(latency time.Duration, err error) {
+	r.Total++
+	if err != nil {
+		r.Failed++
+	}
+	r.Latencies = append(r.Latencies, latency)
+}
+
+// percentile returns p-th (0 < p <= 100) percentile of latencies, using nearest-rank method.
+func (r loadResult) percentile(p float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+
+	sorted := slices.Clone(r.Latencies)
+	slices.Sort(sorted)
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// probe sends the example request once and records it.
+func probe(ctx context.Context, client *http.Client, baseURL string, ex example) (loadResult, error) {
+	var result loadResult
+
+	start := time.Now()
+	err := check(ctx, client, baseURL, ex)
+	result.record(time.Since(start), err)
+
+	return result, err
+}
+
+// load sends the example request at constant rate of rpm for given duration.
+func load(ctx context.Context, client *http.Client, baseURL string, ex example, rpm uint64, duration time.Duration) (loadResult, error) {
+	if rpm == 0 {
+		return loadResult{}, errors.New("rpm should be positive")
+	}
+
+	ticker := time.NewTicker(time.Minute / time.Duration(rpm))
+	defer ticker.Stop()
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		result loadResult
+	)
+
+	send := func() {
+		defer wg.Done()
+
+		start := time.Now()
+		err := check(ctx, client, baseURL, ex)
+		latency := time.Since(start)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		result.record(latency, err)
+	}
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-timer.C:
+			break loop
+		case <-ticker.C:
+			wg.Add(1)
+			go send()
+		}
+	}
+
+	wg.Wait()
+
+	return result, ctx.Err()
+}
+
+func (r loadResult) String() string {
+	return fmt.Sprintf("%d/%d requests failed", r.Failed, r.Total)
+}
@@ -0,0 +1,118 @@
+package runner
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestSectionSuite(t *testing.T) {
+	suite.Run(t, new(SectionSuite))
+}
+
+type SectionSuite struct {
+	suite.Suite
+
+	server *httptest.Server
+}
+
+func (s *SectionSuite) SetupTest() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /echo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.Copy(w, r.Body)
+	})
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.server = httptest.NewServer(mux)
+}
+
+func (s *SectionSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *SectionSuite) TestCheck() {
+	testcases := []struct {
+		desc    string
+		example string
+		wantErr bool
+	}{
+		{
+			desc:    "default example",
+			example: "",
+			wantErr: false,
+		},
+		{
+			desc: "matching body",
+			example: `{
+				"request": {"method": "POST", "path": "/echo", "body": {"a": 1}},
+				"response": {"status": 200, "body": {"a": 1}}
+			}`,
+			wantErr: false,
+		},
+		{
+			desc: "mismatching body",
+			example: `{
+				"request": {"method": "POST", "path": "/echo", "body": {"a": 1}},
+				"response": {"status": 200, "body": {"a": 2}}
+			}`,
+			wantErr: true,
+		},
+		{
+			desc: "mismatching status",
+			example: `{
+				"request": {"path": "/"},
+				"response": {"status": 201}
+			}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			ex, err := parseExample(tc.example)
+			s.Require().NoError(err)
+
+			err = check(context.Background(), s.server.Client(), s.server.URL, ex)
+			if tc.wantErr {
+				s.Error(err)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
+
+func (s *SectionSuite) TestLoad() {
+	ex, err := parseExample("")
+	s.Require().NoError(err)
+
+	// 6000 rpm is a request per 10ms.
+	result, err := load(context.Background(), s.server.Client(), s.server.URL, ex, 6000, 105*time.Millisecond)
+	s.Require().NoError(err)
+
+	s.Zero(result.Failed)
+	s.InDelta(10, result.Total, 2)
+	s.Len(result.Latencies, result.Total)
+}
+
+func (s *SectionSuite) TestPercentile() {
+	var result loadResult
+	s.Zero(result.percentile(50))
+
+	for i := 1; i <= 100; i++ {
+		result.record(time.Duration(i)*time.Millisecond, nil)
+	}
+
+	s.Equal(50*time.Millisecond, result.percentile(50))
+	s.Equal(95*time.Millisecond, result.percentile(95))
+	s.Equal(99*time.Millisecond, result.percentile(99))
+	s.Equal(100*time.Millisecond, result.percentile(100))
+}
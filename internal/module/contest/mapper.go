@@ -0,0 +1,76 @@
+package contest_module
+
+import (
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+)
+
+func toContestListOutput(contests []domain.Contest) *dto.ContestListOutput {
+	out := make(dto.ContestListOutput, len(contests))
+	for i, contest := range contests {
+		out[i] = dto.ContestListElem{
+			ID:      contest.ID.String(),
+			Title:   contest.Title,
+			StartAt: contest.StartAt,
+			EndAt:   contest.EndAt,
+		}
+	}
+
+	return &out
+}
+
+func toContestOutput(contest domain.Contest) *dto.ContestOutput {
+	tasks := make([]dto.TaskListElem, len(contest.Tasks))
+	for i, task := range contest.Tasks {
+		tasks[i] = dto.TaskListElem{
+			ID:    task.ID.String(),
+			Title: task.Title,
+		}
+	}
+
+	return &dto.ContestOutput{
+		ID:       contest.ID.String(),
+		Title:    contest.Title,
+		StartAt:  contest.StartAt,
+		EndAt:    contest.EndAt,
+		FreezeAt: contest.FreezeAt,
+		Tasks:    tasks,
+	}
+}
+
+func toScoreboardOutput(contest domain.Contest, standings []standing, frozen bool) *dto.ScoreboardOutput {
+	out := &dto.ScoreboardOutput{
+		Frozen:    frozen,
+		Standings: make([]dto.ScoreboardElem, len(standings)),
+	}
+
+	for i, standing := range standings {
+		tasks := make([]dto.ScoreboardTask, len(contest.Tasks))
+		for j, task := range contest.Tasks {
+			tasks[j] = dto.ScoreboardTask{
+				TaskID: task.ID.String(),
+				Score:  standing.scores[j],
+			}
+		}
+
+		out.Standings[i] = dto.ScoreboardElem{
+			Rank:  i + 1,
+			Score: standing.total,
+			User: dto.UserInfo{
+				ID:         standing.user.ID.String(),
+				Username:   standing.user.Username,
+				ProfileURL: standing.user.ProfileURL,
+				Role:       standing.user.Role.String(),
+			},
+			Tasks: tasks,
+		}
+	}
+
+	return out
+}
+
+func toIDOutput(contest domain.Contest) *dto.IDOutput {
+	return &dto.IDOutput{
+		ID: contest.ID.String(),
+	}
+}
@@ -0,0 +1,77 @@
+package contest_module
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+)
+
+// standing is a row of the scoreboard.
+type standing struct {
+	user  domain.User
+	total uint64
+	// scores are indexed the same as tasks of the contest.
+	// It is nil if the user has no scored submission on the task.
+	scores []*uint64
+	// reachedAt is when the user reached the total.
+	reachedAt time.Time
+}
+
+// rankStandings sums up the best score of each task by users.
+// submissions[i] should be scored submissions of i-th task of the contest,
+// ordered by score desc, then timestamp asc. Submissions should include User field.
+// Only submissions made in [from, to) are counted.
+// Standings are ordered by total desc, then reachedAt asc.
+func rankStandings(taskCount int, submissions [][]domain.Submission, from, to time.Time) []standing {
+	standings := make([]standing, 0)
+	indexOf := make(map[uuid.UUID]int)
+
+	for taskIdx, group := range submissions {
+		for _, submission := range group {
+			if submission.Timestamp.Before(from) || !submission.Timestamp.Before(to) {
+				continue
+			}
+
+			idx, ok := indexOf[submission.UserID]
+			if !ok {
+				idx = len(standings)
+				indexOf[submission.UserID] = idx
+
+				standings = append(standings, standing{
+					user:   *submission.User,
+					scores: make([]*uint64, taskCount),
+				})
+			}
+
+			s := &standings[idx]
+
+			// The first one is the best.
+			if s.scores[taskIdx] != nil {
+				continue
+			}
+
+			score := *submission.Score
+			s.scores[taskIdx] = &score
+			s.total += score
+
+			if submission.Timestamp.After(s.reachedAt) {
+				s.reachedAt = submission.Timestamp
+			}
+		}
+	}
+
+	slices.SortStableFunc(standings, func(a, b standing) int {
+		if a.total != b.total {
+			if a.total > b.total {
+				return -1
+			}
+			return 1
+		}
+
+		return a.reachedAt.Compare(b.reachedAt)
+	})
+
+	return standings
+}
@@ -0,0 +1,81 @@
+package contest_module
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankStandings(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	alice := domain.User{ID: uuid.New(), Username: "alice"}
+	bob := domain.User{ID: uuid.New(), Username: "bob"}
+
+	scored := func(user domain.User, score uint64, after time.Duration) domain.Submission {
+		return domain.Submission{
+			ID:        uuid.New(),
+			Timestamp: start.Add(after),
+			Score:     &score,
+			UserID:    user.ID,
+			User:      &user,
+		}
+	}
+
+	testcases := []struct {
+		desc        string
+		submissions [][]domain.Submission
+		to          time.Time
+		expected    []string
+		totals      []uint64
+	}{
+		{
+			desc: "sums best score of each task",
+			submissions: [][]domain.Submission{
+				{scored(alice, 100, time.Minute), scored(bob, 80, 2*time.Minute), scored(alice, 50, 3*time.Minute)},
+				{scored(bob, 100, 5*time.Minute)},
+			},
+			to:       end,
+			expected: []string{"bob", "alice"},
+			totals:   []uint64{180, 100},
+		},
+		{
+			desc: "earlier one wins on tie",
+			submissions: [][]domain.Submission{
+				{scored(bob, 100, 2*time.Minute), scored(alice, 100, 3*time.Minute)},
+			},
+			to:       end,
+			expected: []string{"bob", "alice"},
+			totals:   []uint64{100, 100},
+		},
+		{
+			desc: "ignores submissions out of range",
+			submissions: [][]domain.Submission{
+				{scored(alice, 100, -time.Minute), scored(bob, 100, 40*time.Minute), scored(alice, 50, 10*time.Minute)},
+			},
+			to:       start.Add(30 * time.Minute),
+			expected: []string{"alice"},
+			totals:   []uint64{50},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			standings := rankStandings(len(tc.submissions), tc.submissions, start, tc.to)
+
+			usernames := make([]string, len(standings))
+			totals := make([]uint64, len(standings))
+			for i, s := range standings {
+				usernames[i] = s.user.Username
+				totals[i] = s.total
+			}
+
+			assert.Equal(t, tc.expected, usernames)
+			assert.Equal(t, tc.totals, totals)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package contest_module
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/status"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/tx"
+	"github.com/pkg/errors"
+)
+
+type contestUsecase struct {
+	lock tx.Locker
+
+	contestRepository    domain.ContestRepository
+	taskRepository       domain.TaskRepository
+	submissionRepository domain.SubmissionRepository
+}
+
+var _ domain.ContestUsecase = (*contestUsecase)(nil)
+
+func NewContestUsecase(
+	cr domain.ContestRepository, tr domain.TaskRepository,
+	sr domain.SubmissionRepository, l tx.Locker,
+) *contestUsecase {
+	return &contestUsecase{
+		contestRepository:    cr,
+		taskRepository:       tr,
+		submissionRepository: sr,
+		lock:                 l,
+	}
+}
+
+func (u *contestUsecase) GetList(ctx context.Context) (out *dto.ContestListOutput, err error) {
+	contests, err := u.contestRepository.FetchAll(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching contests")
+	}
+
+	return toContestListOutput(contests), nil
+}
+
+func (u *contestUsecase) GetContest(ctx context.Context, in dto.IDInput) (out *dto.ContestOutput, err error) {
+	contest, err := u.fetchContest(ctx, uuid.MustParse(in.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	return toContestOutput(contest), nil
+}
+
+func (u *contestUsecase) CreateContest(ctx context.Context, in dto.ContestInput) (out *dto.IDOutput, err error) {
+	ctx, err = tx.NewAtomic(ctx, tx.AtomicOpts{
+		ReadOnly: false,
+		DataSources: []any{
+			u.contestRepository,
+			u.taskRepository,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "starting atomic transaction")
+	}
+	defer tx.Evaluate(ctx, &err)
+
+	contest, err := u.toContest(ctx, uuid.New(), in)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.contestRepository.Create(ctx, contest); err != nil {
+		return nil, errors.Wrap(err, "creating contest")
+	}
+
+	return toIDOutput(contest), nil
+}
+
+func (u *contestUsecase) UpdateContest(ctx context.Context, in dto.UpdateContestInput) (err error) {
+	contestID := uuid.MustParse(in.ID)
+
+	ctx, err = tx.NewAtomic(ctx, tx.AtomicOpts{
+		ReadOnly: false,
+		DataSources: []any{
+			u.contestRepository,
+			u.taskRepository,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "starting atomic transaction")
+	}
+	defer tx.Evaluate(ctx, &err)
+
+	ctx, release, err := u.lock.Acquire(ctx, "contest", contestID.String())
+	if err != nil {
+		return errors.Wrap(err, "acquiring lock")
+	}
+	defer release()
+
+	if _, err := u.fetchContest(ctx, contestID); err != nil {
+		return err
+	}
+
+	contest, err := u.toContest(ctx, contestID, in.ContestInput)
+	if err != nil {
+		return err
+	}
+
+	if err := u.contestRepository.Update(ctx, contest); err != nil {
+		return errors.Wrap(err, "updating contest")
+	}
+
+	return nil
+}
+
+func (u *contestUsecase) GetScoreboard(ctx context.Context, in dto.IDInput) (out *dto.ScoreboardOutput, err error) {
+	contest, err := u.fetchContest(ctx, uuid.MustParse(in.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	// Submissions made after the freeze are hidden until the contest ends.
+	frozen := contest.IsFrozen(now)
+	until := contest.EndAt
+	if frozen {
+		until = *contest.FreezeAt
+	}
+
+	submissions := make([][]domain.Submission, len(contest.Tasks))
+	for idx, task := range contest.Tasks {
+		submissions[idx], err = u.submissionRepository.FetchAllScored(ctx, task.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetching scored submissions")
+		}
+	}
+
+	standings := rankStandings(len(contest.Tasks), submissions, contest.StartAt, until)
+
+	return toScoreboardOutput(contest, standings, frozen), nil
+}
+
+func (u *contestUsecase) fetchContest(ctx context.Context, id uuid.UUID) (domain.Contest, error) {
+	contest, err := u.contestRepository.FetchByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrContestNotFound) {
+			return domain.Contest{}, status.NewErr(http.StatusNotFound, err.Error())
+		}
+
+		return domain.Contest{}, errors.Wrap(err, "fetching contest")
+	}
+
+	return contest, nil
+}
+
+// toContest validates the input and converts it to the contest.
+func (u *contestUsecase) toContest(ctx context.Context, id uuid.UUID, in dto.ContestInput) (domain.Contest, error) {
+	if in.FreezeAt != nil && (in.FreezeAt.Before(in.StartAt) || in.FreezeAt.After(in.EndAt)) {
+		return domain.Contest{}, status.NewErr(http.StatusBadRequest, "freeze time should be within the contest")
+	}
+
+	contest := domain.Contest{
+		ID:       id,
+		Title:    in.Title,
+		StartAt:  in.StartAt,
+		EndAt:    in.EndAt,
+		FreezeAt: in.FreezeAt,
+		Tasks:    make([]domain.Task, len(in.TaskIDs)),
+	}
+
+	for idx, rawID := range in.TaskIDs {
+		taskID := uuid.MustParse(rawID)
+
+		exists, err := u.taskRepository.ExistsByID(ctx, taskID)
+		if err != nil {
+			return domain.Contest{}, errors.Wrap(err, "checking task exists")
+		}
+
+		if !exists {
+			return domain.Contest{}, status.NewErr(http.StatusNotFound, domain.ErrTaskNotFound.Error())
+		}
+
+		contest.Tasks[idx] = domain.Task{ID: taskID}
+	}
+
+	return contest, nil
+}
@@ -0,0 +1,147 @@
+package contest_module_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/status"
+	contest_module "github.com/oneee-playground/r2d2-api-server/internal/module/contest"
+	"github.com/oneee-playground/r2d2-api-server/test/mocks"
+	"github.com/oneee-playground/r2d2-api-server/test/stubs"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestContestUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(ContestUsecaseSuite))
+}
+
+type ContestUsecaseSuite struct {
+	suite.Suite
+
+	usecase domain.ContestUsecase
+
+	ctl  *gomock.Controller
+	mock struct {
+		contestRepository    *mocks.MockContestRepository
+		taskRepository       *mocks.MockTaskRepository
+		submissionRepository *mocks.MockSubmissionRepository
+	}
+	stub struct {
+		locker *stubs.StubLocker
+	}
+}
+
+func (s *ContestUsecaseSuite) SetupTest() {
+	s.ctl = gomock.NewController(s.T())
+	s.mock.contestRepository = mocks.NewMockContestRepository(s.ctl)
+	s.mock.taskRepository = mocks.NewMockTaskRepository(s.ctl)
+	s.mock.submissionRepository = mocks.NewMockSubmissionRepository(s.ctl)
+	s.stub.locker = stubs.NewStubLocker()
+
+	s.usecase = contest_module.NewContestUsecase(
+		s.mock.contestRepository, s.mock.taskRepository,
+		s.mock.submissionRepository, s.stub.locker,
+	)
+}
+
+func (s *ContestUsecaseSuite) TestCreateContest() {
+	start := time.Now()
+	end := start.Add(time.Hour)
+	lateFreeze := end.Add(time.Minute)
+
+	taskIDs := []string{uuid.NewString(), uuid.NewString()}
+
+	testcases := []struct {
+		desc     string
+		in       dto.ContestInput
+		setup    func()
+		checkErr func(err error) bool
+	}{
+		{
+			desc: "success",
+			in:   dto.ContestInput{Title: "contest", StartAt: start, EndAt: end, TaskIDs: taskIDs},
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					ExistsByID(gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+				s.mock.contestRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, contest domain.Contest) {
+						s.Len(contest.Tasks, 2)
+						s.Equal(taskIDs[0], contest.Tasks[0].ID.String())
+					}).
+					Return(nil)
+			},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc:  "freeze after the end",
+			in:    dto.ContestInput{Title: "contest", StartAt: start, EndAt: end, FreezeAt: &lateFreeze, TaskIDs: taskIDs},
+			setup: func() {},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusBadRequest
+			},
+		},
+		{
+			desc: "task does not exist",
+			in:   dto.ContestInput{Title: "contest", StartAt: start, EndAt: end, TaskIDs: taskIDs},
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					ExistsByID(gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusNotFound
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			tc.setup()
+
+			_, err := s.usecase.CreateContest(context.Background(), tc.in)
+			s.True(tc.checkErr(err), err)
+		})
+	}
+}
+
+func (s *ContestUsecaseSuite) TestGetScoreboard() {
+	user := domain.User{ID: uuid.New(), Role: domain.RoleMember}
+	task := domain.Task{ID: uuid.New()}
+
+	start := time.Now().Add(-time.Hour)
+	freeze := time.Now().Add(-time.Minute)
+
+	contest := domain.Contest{
+		ID:       uuid.New(),
+		StartAt:  start,
+		EndAt:    time.Now().Add(time.Hour),
+		FreezeAt: &freeze,
+		Tasks:    []domain.Task{task},
+	}
+
+	before, after := uint64(50), uint64(100)
+	submissions := []domain.Submission{
+		{ID: uuid.New(), Timestamp: time.Now(), Score: &after, UserID: user.ID, User: &user},
+		{ID: uuid.New(), Timestamp: start.Add(time.Minute), Score: &before, UserID: user.ID, User: &user},
+	}
+
+	s.mock.contestRepository.EXPECT().
+		FetchByID(gomock.Any(), contest.ID).Return(contest, nil)
+	s.mock.submissionRepository.EXPECT().
+		FetchAllScored(gomock.Any(), task.ID).Return(submissions, nil)
+
+	out, err := s.usecase.GetScoreboard(context.Background(), dto.IDInput{ID: contest.ID.String()})
+	s.Require().NoError(err)
+
+	s.True(out.Frozen)
+	s.Require().Len(out.Standings, 1)
+	s.Equal(before, out.Standings[0].Score)
+}
@@ -14,6 +14,9 @@ type BuildOpts struct {
 	Repository string    `json:"repository"`
 	CommitHash string    `json:"commitHash"`
 	Platform   string    `json:"platform"`
+	// Token is installation token of github app, which can only read the repository.
+	// It is empty if the repository is accessed anonymously.
+	Token string `json:"token,omitempty"`
 }
 
 type ImageBuilder interface {
@@ -22,3 +25,11 @@ type ImageBuilder interface {
 	// Result of build should be informed with eventbus.
 	RequestBuild(ctx context.Context, opts BuildOpts) error
 }
+
+// BuildCanceller is optionally implemented by ImageBuilder
+// which is able to stop the build in progress.
+type BuildCanceller interface {
+	// CancelBuild stops the build of the submission.
+	// It should not fail if there is no such build.
+	CancelBuild(ctx context.Context, submissionID uuid.UUID) error
+}
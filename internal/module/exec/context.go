@@ -15,6 +15,14 @@ type ExecContext struct {
 
 	Repository string `json:"repositoy"`
 	CommitHash string `json:"commitHash"`
+
+	// RevisionID is the task revision the submission is judged against.
+	// If nil, current sections and resources of the task are used.
+	RevisionID *uuid.UUID `json:"revisionID"`
+
+	// Cancelled is set when the submission is cancelled during execution.
+	// Results arriving after it should be dropped.
+	Cancelled bool `json:"cancelled"`
 }
 
 var (
@@ -23,6 +31,10 @@ var (
 
 type ExecContextStroage interface {
 	Get(ctx context.Context, submissionID uuid.UUID) (ExecContext, error)
-	Set(ctx context.Context, submissionID uuid.UUID, execCtx ExecContext) error
+	// Create stores the context only if there is none. It reports whether it is stored.
+	Create(ctx context.Context, submissionID uuid.UUID, execCtx ExecContext) (bool, error)
+	// Cancel marks the context as cancelled atomically. It reports whether the context existed.
+	// If it didn't, a cancelled context is left for a while so that execution won't start.
+	Cancel(ctx context.Context, submissionID uuid.UUID) (bool, error)
 	Delete(ctx context.Context, submissionID uuid.UUID) error
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/oneee-playground/r2d2-api-server/internal/domain"
 	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	submission_module "github.com/oneee-playground/r2d2-api-server/internal/module/submission"
 	"github.com/pkg/errors"
 )
 
@@ -16,31 +17,38 @@ type EventHandler struct {
 	submissionRepository domain.SubmissionRepository
 	sectionRepository    domain.SectionRepository
 	resourceRepository   domain.ResourceRepository
+	revisionRepository   domain.TaskRevisionRepository
+	userRepository       domain.UserRepository
 
 	eventPublisher event.Publisher
 	jobQueue       JobQueue
 	imageBuilder   ImageBuilder
 	contextStorage ExecContextStroage
+	tokenIssuer    submission_module.InstallationTokenIssuer
 }
 
 func NewEventHandler(
-	sur domain.SubmissionRepository, ser domain.SectionRepository, rr domain.ResourceRepository,
-	ep event.Publisher, jq JobQueue, ib ImageBuilder, cs ExecContextStroage,
+	sur domain.SubmissionRepository, ser domain.SectionRepository,
+	rr domain.ResourceRepository, trr domain.TaskRevisionRepository, ur domain.UserRepository,
+	ep event.Publisher, jq JobQueue, ib ImageBuilder, cs ExecContextStroage, ti submission_module.InstallationTokenIssuer,
 ) *EventHandler {
 	return &EventHandler{
 		submissionRepository: sur,
 		sectionRepository:    ser,
 		resourceRepository:   rr,
+		revisionRepository:   trr,
+		userRepository:       ur,
 		eventPublisher:       ep,
 		jobQueue:             jq,
 		imageBuilder:         ib,
 		contextStorage:       cs,
+		tokenIssuer:          ti,
 	}
 }
 
 func (h *EventHandler) Register(ctx context.Context, subscriber event.Subscriber) error {
 	if err := subscriber.Subscribe(ctx, event.TopicSubmission,
-		h.StartBuild,
+		h.StartBuild, h.CancelExecution,
 	); err != nil {
 		return err
 	}
@@ -70,14 +78,51 @@ func (h *EventHandler) StartBuild(ctx context.Context, topic event.Topic, payloa
 		return event.NoErrSkipHandler
 	}
 
-	err := h.publishSubmissionEvent(ctx, domain.KindBuildStart, "", ev.SubmissionID, ev.UserID)
+	submission, err := h.submissionRepository.FetchByID(ctx, ev.SubmissionID)
+	if err != nil {
+		return errors.Wrap(err, "fetching submission")
+	}
+
+	if submission.IsCancelled {
+		// Cancelled before build has started.
+		return event.NoErrSkipHandler
+	}
+
+	token, err := h.issueToken(ctx, submission)
 	if err != nil {
 		return err
 	}
 
-	submission, err := h.submissionRepository.FetchByID(ctx, ev.SubmissionID)
+	execCtx := ExecContext{
+		TaskID:     submission.TaskID,
+		Repository: submission.Repository,
+		CommitHash: submission.CommitHash,
+		UserID:     submission.UserID,
+		RevisionID: submission.RevisionID,
+	}
+
+	// Build result could be published before RequestBuild returns.
+	// So the context should be stored first.
+	created, err := h.contextStorage.Create(ctx, submission.ID, execCtx)
 	if err != nil {
-		return errors.Wrap(err, "fetching submission")
+		return errors.Wrap(err, "creating exec context")
+	}
+
+	if !created {
+		// It is either cancelled after the submission was fetched, or stored by previous delivery.
+		existing, err := h.contextStorage.Get(ctx, submission.ID)
+		if err != nil {
+			return errors.Wrap(err, "fetching exec context")
+		}
+
+		if existing.Cancelled {
+			return event.NoErrSkipHandler
+		}
+	}
+
+	err = h.publishSubmissionEvent(ctx, domain.KindBuildStart, "", ev.SubmissionID, ev.UserID)
+	if err != nil {
+		return err
 	}
 
 	buildOpts := BuildOpts{
@@ -86,21 +131,21 @@ func (h *EventHandler) StartBuild(ctx context.Context, topic event.Topic, payloa
 		Repository: submission.Repository,
 		CommitHash: submission.CommitHash,
 		Platform:   runtime.GOOS + "/" + runtime.GOARCH,
+		Token:      token,
 	}
 
 	if err := h.imageBuilder.RequestBuild(ctx, buildOpts); err != nil {
 		return errors.Wrap(err, "requesting to build image")
 	}
 
-	execCtx := ExecContext{
-		TaskID:     submission.TaskID,
-		Repository: submission.Repository,
-		CommitHash: submission.CommitHash,
-		UserID:     submission.UserID,
+	// Cancellation could arrive before the build was requested, when there was nothing to stop.
+	current, err := h.contextStorage.Get(ctx, submission.ID)
+	if err != nil {
+		return errors.Wrap(err, "fetching exec context")
 	}
 
-	if err := h.contextStorage.Set(ctx, submission.ID, execCtx); err != nil {
-		return errors.Wrap(err, "setting exec context")
+	if current.Cancelled {
+		return h.cancelBuild(ctx, submission.ID)
 	}
 
 	return nil
@@ -123,6 +168,10 @@ func (h *EventHandler) EnqueueJob(ctx context.Context, topic event.Topic, payloa
 		return errors.Wrap(err, "fetching exec context")
 	}
 
+	if execCtx.Cancelled {
+		return h.deleteExecContext(ctx, ev.ID)
+	}
+
 	err = h.publishSubmissionEvent(ctx, domain.KindBuildSuccess, "", submissionID, execCtx.UserID)
 	if err != nil {
 		return err
@@ -137,27 +186,21 @@ func (h *EventHandler) EnqueueJob(ctx context.Context, topic event.Topic, payloa
 		},
 	}
 
-	fetchSectionOpts := domain.FetchSectionsOption{IncludeContent: false}
-
-	sections, err := h.sectionRepository.FetchAllByTaskID(ctx, execCtx.TaskID, fetchSectionOpts)
+	sections, resources, err := h.fetchDefinition(ctx, execCtx)
 	if err != nil {
-		return errors.Wrap(err, "fetching sections")
+		return err
 	}
 
 	job.Sections = make([]Section, len(sections))
 	for idx, section := range sections {
 		job.Sections[idx] = Section{
-			ID:   section.ID,
-			Type: section.Type,
-			RPM:  section.RPM,
+			ID:      section.ID,
+			Type:    section.Type,
+			RPM:     section.RPM,
+			Example: section.Example,
 		}
 	}
 
-	resources, err := h.resourceRepository.FetchAllByTaskID(ctx, execCtx.TaskID)
-	if err != nil {
-		return errors.Wrap(err, "fetching resources")
-	}
-
 	job.Resources = make([]Resource, len(resources))
 	for idx, resource := range resources {
 		job.Resources[idx] = Resource{
@@ -177,6 +220,34 @@ func (h *EventHandler) EnqueueJob(ctx context.Context, topic event.Topic, payloa
 	return h.publishSubmissionEvent(ctx, domain.KindTestStart, "", submissionID, execCtx.UserID)
 }
 
+// fetchDefinition returns sections and resources the submission is judged against.
+// It uses the revision snapshot if exists, so that changes made after submission don't matter.
+func (h *EventHandler) fetchDefinition(ctx context.Context, execCtx ExecContext) ([]domain.Section, []domain.Resource, error) {
+	if execCtx.RevisionID != nil {
+		revision, err := h.revisionRepository.FetchByID(ctx, *execCtx.RevisionID)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "fetching revision")
+		}
+
+		return revision.Sections, revision.Resources, nil
+	}
+
+	// Examples are needed to test sections.
+	fetchSectionOpts := domain.FetchSectionsOption{IncludeContent: true}
+
+	sections, err := h.sectionRepository.FetchAllByTaskID(ctx, execCtx.TaskID, fetchSectionOpts)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "fetching sections")
+	}
+
+	resources, err := h.resourceRepository.FetchAllByTaskID(ctx, execCtx.TaskID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "fetching resources")
+	}
+
+	return sections, resources, nil
+}
+
 func (h *EventHandler) NotifyBuildFailure(ctx context.Context, topic event.Topic, payload []byte) error {
 	var ev event.ExecEvent
 	if err := json.Unmarshal(payload, &ev); err != nil {
@@ -192,6 +263,10 @@ func (h *EventHandler) NotifyBuildFailure(ctx context.Context, topic event.Topic
 		return errors.Wrap(err, "fetching exec context")
 	}
 
+	if execCtx.Cancelled {
+		return h.deleteExecContext(ctx, ev.ID)
+	}
+
 	err = h.publishSubmissionEvent(ctx, domain.KindBuildFail, ev.Extra, ev.ID, execCtx.UserID)
 	if err != nil {
 		return err
@@ -211,6 +286,10 @@ func (h *EventHandler) NotifyTestResult(ctx context.Context, topic event.Topic,
 		return errors.Wrap(err, "fetching exec context")
 	}
 
+	if execCtx.Cancelled {
+		return h.deleteExecContext(ctx, ev.ID)
+	}
+
 	var eventKind domain.EventKind
 	if ev.Success {
 		eventKind = domain.KindTestSuccess
@@ -226,6 +305,54 @@ func (h *EventHandler) NotifyTestResult(ctx context.Context, topic event.Topic,
 	return h.deleteExecContext(ctx, ev.ID)
 }
 
+// CancelExecution marks execution of the cancelled submission,
+// and tells builder and job queue to stop it if they are able to.
+func (h *EventHandler) CancelExecution(ctx context.Context, topic event.Topic, payload []byte) error {
+	var ev event.SubmissionEvent
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		return errors.Wrap(err, "unmarshalling payload")
+	}
+
+	if ev.Kind != domain.KindCancel {
+		return event.NoErrSkipHandler
+	}
+
+	// Cancelled context is left even if execution has not started yet,
+	// so build requested concurrently is stopped.
+	started, err := h.contextStorage.Cancel(ctx, ev.SubmissionID)
+	if err != nil {
+		return errors.Wrap(err, "cancelling exec context")
+	}
+
+	if !started {
+		// Execution has not started yet, or is already over.
+		return event.NoErrSkipHandler
+	}
+
+	if err := h.cancelBuild(ctx, ev.SubmissionID); err != nil {
+		return err
+	}
+
+	if canceller, ok := h.jobQueue.(JobCanceller); ok {
+		if err := canceller.CancelJob(ctx, ev.SubmissionID); err != nil {
+			return errors.Wrap(err, "cancelling job")
+		}
+	}
+
+	return nil
+}
+
+// cancelBuild tells builder to stop the build if it is able to.
+func (h *EventHandler) cancelBuild(ctx context.Context, id uuid.UUID) error {
+	if canceller, ok := h.imageBuilder.(BuildCanceller); ok {
+		if err := canceller.CancelBuild(ctx, id); err != nil {
+			return errors.Wrap(err, "cancelling build")
+		}
+	}
+
+	return nil
+}
+
 func (h *EventHandler) deleteExecContext(ctx context.Context, id uuid.UUID) error {
 	if err := h.contextStorage.Delete(ctx, id); err != nil {
 		return errors.Wrap(err, "deleting exec context")
@@ -252,3 +379,28 @@ func (h *EventHandler) publishSubmissionEvent(
 
 	return nil
 }
+
+// issueToken issues installation token for the repository if the user has installed github app.
+// It returns empty token if the repository should be accessed anonymously.
+func (h *EventHandler) issueToken(ctx context.Context, submission domain.Submission) (string, error) {
+	user, err := h.userRepository.FetchByID(ctx, submission.UserID)
+	if err != nil {
+		return "", errors.Wrap(err, "fetching user")
+	}
+
+	if user.InstallationID == nil {
+		return "", nil
+	}
+
+	token, err := h.tokenIssuer.IssueInstallationToken(ctx, *user.InstallationID, submission.Repository)
+	if err != nil {
+		// Public repositories are accessible without the app.
+		if errors.Is(err, submission_module.ErrRepositoryNotFound) {
+			return "", nil
+		}
+
+		return "", errors.Wrap(err, "issuing installation token")
+	}
+
+	return token, nil
+}
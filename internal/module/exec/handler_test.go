@@ -0,0 +1,351 @@
+package exec_module_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	exec_module "github.com/oneee-playground/r2d2-api-server/internal/module/exec"
+	submission_module "github.com/oneee-playground/r2d2-api-server/internal/module/submission"
+	"github.com/oneee-playground/r2d2-api-server/test/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestExecEventHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ExecEventHandlerSuite))
+}
+
+type ExecEventHandlerSuite struct {
+	suite.Suite
+
+	handler *exec_module.EventHandler
+
+	ctl  *gomock.Controller
+	mock struct {
+		submissionRepository *mocks.MockSubmissionRepository
+		sectionRepository    *mocks.MockSectionRepository
+		resourceRepository   *mocks.MockResourceRepository
+		revisionRepository   *mocks.MockTaskRevisionRepository
+		userRepository       *mocks.MockUserRepository
+		eventPublisher       *mocks.MockPublisher
+		jobQueue             *mocks.MockJobQueue
+		contextStorage       *mocks.MockExecContextStroage
+		tokenIssuer          *mocks.MockInstallationTokenIssuer
+	}
+	builder *cancellableBuilder
+}
+
+// cancellableBuilder records cancelled builds.
+type cancellableBuilder struct {
+	*mocks.MockImageBuilder
+	cancelled []uuid.UUID
+}
+
+func (b *cancellableBuilder) CancelBuild(ctx context.Context, submissionID uuid.UUID) error {
+	b.cancelled = append(b.cancelled, submissionID)
+	return nil
+}
+
+func (s *ExecEventHandlerSuite) SetupTest() {
+	s.ctl = gomock.NewController(s.T())
+	s.mock.submissionRepository = mocks.NewMockSubmissionRepository(s.ctl)
+	s.mock.sectionRepository = mocks.NewMockSectionRepository(s.ctl)
+	s.mock.resourceRepository = mocks.NewMockResourceRepository(s.ctl)
+	s.mock.revisionRepository = mocks.NewMockTaskRevisionRepository(s.ctl)
+	s.mock.userRepository = mocks.NewMockUserRepository(s.ctl)
+	s.mock.eventPublisher = mocks.NewMockPublisher(s.ctl)
+	s.mock.jobQueue = mocks.NewMockJobQueue(s.ctl)
+	s.mock.contextStorage = mocks.NewMockExecContextStroage(s.ctl)
+	s.mock.tokenIssuer = mocks.NewMockInstallationTokenIssuer(s.ctl)
+	s.builder = &cancellableBuilder{MockImageBuilder: mocks.NewMockImageBuilder(s.ctl)}
+
+	s.handler = exec_module.NewEventHandler(
+		s.mock.submissionRepository, s.mock.sectionRepository,
+		s.mock.resourceRepository, s.mock.revisionRepository, s.mock.userRepository,
+		s.mock.eventPublisher, s.mock.jobQueue, s.builder, s.mock.contextStorage, s.mock.tokenIssuer,
+	)
+}
+
+func (s *ExecEventHandlerSuite) TestStartBuildIssuesToken() {
+	installationID := int64(1)
+
+	// Approved submissions are already done.
+	submission := domain.Submission{ID: uuid.New(), UserID: uuid.New(), Repository: "owner/repo", IsDone: true}
+
+	testcases := []struct {
+		desc  string
+		user  domain.User
+		setup func()
+		token string
+	}{
+		{
+			desc:  "app not installed",
+			user:  domain.User{},
+			setup: func() {},
+			token: "",
+		},
+		{
+			desc: "app installed",
+			user: domain.User{InstallationID: &installationID},
+			setup: func() {
+				s.mock.tokenIssuer.EXPECT().
+					IssueInstallationToken(gomock.Any(), installationID, submission.Repository).Return("token", nil)
+			},
+			token: "token",
+		},
+		{
+			desc: "repository without the app",
+			user: domain.User{InstallationID: &installationID},
+			setup: func() {
+				s.mock.tokenIssuer.EXPECT().
+					IssueInstallationToken(gomock.Any(), installationID, submission.Repository).
+					Return("", submission_module.ErrRepositoryNotFound)
+			},
+			token: "",
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			tc.setup()
+
+			s.mock.submissionRepository.EXPECT().
+				FetchByID(gomock.Any(), submission.ID).Return(submission, nil)
+			s.mock.userRepository.EXPECT().
+				FetchByID(gomock.Any(), submission.UserID).Return(tc.user, nil)
+			s.mock.eventPublisher.EXPECT().
+				Publish(gomock.Any(), event.TopicSubmission, gomock.Any()).Return(nil)
+			gomock.InOrder(
+				s.mock.contextStorage.EXPECT().
+					Create(gomock.Any(), submission.ID, gomock.Any()).Return(true, nil),
+				s.builder.EXPECT().
+					RequestBuild(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, opts exec_module.BuildOpts) {
+						s.Equal(tc.token, opts.Token)
+					}).
+					Return(nil),
+				s.mock.contextStorage.EXPECT().
+					Get(gomock.Any(), submission.ID).Return(exec_module.ExecContext{}, nil),
+			)
+
+			payload, _ := json.Marshal(event.SubmissionEvent{
+				ID:           uuid.New(),
+				SubmissionID: submission.ID,
+				UserID:       submission.UserID,
+				Kind:         domain.KindApprove,
+			})
+
+			err := s.handler.StartBuild(context.Background(), event.TopicSubmission, payload)
+			s.NoError(err)
+		})
+	}
+}
+
+func (s *ExecEventHandlerSuite) TestStartBuild() {
+	testcases := []struct {
+		desc       string
+		submission domain.Submission
+		setup      func(submission domain.Submission)
+		wantErr    error
+		cancelled  bool
+	}{
+		{
+			desc:       "approved",
+			submission: domain.Submission{ID: uuid.New(), UserID: uuid.New(), IsDone: true},
+			setup: func(submission domain.Submission) {
+				s.mock.userRepository.EXPECT().
+					FetchByID(gomock.Any(), submission.UserID).Return(domain.User{}, nil)
+				s.mock.eventPublisher.EXPECT().
+					Publish(gomock.Any(), event.TopicSubmission, gomock.Any()).Return(nil)
+				gomock.InOrder(
+					s.mock.contextStorage.EXPECT().
+						Create(gomock.Any(), submission.ID, gomock.Any()).Return(true, nil),
+					s.builder.EXPECT().
+						RequestBuild(gomock.Any(), gomock.Any()).Return(nil),
+					s.mock.contextStorage.EXPECT().
+						Get(gomock.Any(), submission.ID).Return(exec_module.ExecContext{}, nil),
+				)
+			},
+			wantErr: nil,
+		},
+		{
+			desc:       "cancelled",
+			submission: domain.Submission{ID: uuid.New(), UserID: uuid.New(), IsDone: true, IsCancelled: true},
+			setup:      func(submission domain.Submission) {},
+			wantErr:    event.NoErrSkipHandler,
+		},
+		{
+			desc:       "cancelled before context is stored",
+			submission: domain.Submission{ID: uuid.New(), UserID: uuid.New(), IsDone: true},
+			setup: func(submission domain.Submission) {
+				s.mock.userRepository.EXPECT().
+					FetchByID(gomock.Any(), submission.UserID).Return(domain.User{}, nil)
+				s.mock.contextStorage.EXPECT().
+					Create(gomock.Any(), submission.ID, gomock.Any()).Return(false, nil)
+				s.mock.contextStorage.EXPECT().
+					Get(gomock.Any(), submission.ID).Return(exec_module.ExecContext{Cancelled: true}, nil)
+			},
+			wantErr: event.NoErrSkipHandler,
+		},
+		{
+			desc:       "cancelled before build is requested",
+			submission: domain.Submission{ID: uuid.New(), UserID: uuid.New(), IsDone: true},
+			setup: func(submission domain.Submission) {
+				s.mock.userRepository.EXPECT().
+					FetchByID(gomock.Any(), submission.UserID).Return(domain.User{}, nil)
+				s.mock.eventPublisher.EXPECT().
+					Publish(gomock.Any(), event.TopicSubmission, gomock.Any()).Return(nil)
+				gomock.InOrder(
+					s.mock.contextStorage.EXPECT().
+						Create(gomock.Any(), submission.ID, gomock.Any()).Return(true, nil),
+					s.builder.EXPECT().
+						RequestBuild(gomock.Any(), gomock.Any()).Return(nil),
+					s.mock.contextStorage.EXPECT().
+						Get(gomock.Any(), submission.ID).Return(exec_module.ExecContext{Cancelled: true}, nil),
+				)
+			},
+			wantErr:   nil,
+			cancelled: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			s.builder.cancelled = nil
+
+			s.mock.submissionRepository.EXPECT().
+				FetchByID(gomock.Any(), tc.submission.ID).Return(tc.submission, nil)
+			tc.setup(tc.submission)
+
+			payload, _ := json.Marshal(event.SubmissionEvent{
+				ID:           uuid.New(),
+				SubmissionID: tc.submission.ID,
+				UserID:       tc.submission.UserID,
+				Kind:         domain.KindApprove,
+			})
+
+			err := s.handler.StartBuild(context.Background(), event.TopicSubmission, payload)
+			s.ErrorIs(err, tc.wantErr)
+
+			if tc.cancelled {
+				s.Equal([]uuid.UUID{tc.submission.ID}, s.builder.cancelled)
+			} else {
+				s.Empty(s.builder.cancelled)
+			}
+		})
+	}
+}
+
+func (s *ExecEventHandlerSuite) TestCancelExecution() {
+	submissionID := uuid.New()
+
+	testcases := []struct {
+		desc      string
+		kind      domain.EventKind
+		setup     func()
+		wantErr   error
+		cancelled bool
+	}{
+		{
+			desc:    "not a cancel event",
+			kind:    domain.KindApprove,
+			setup:   func() {},
+			wantErr: event.NoErrSkipHandler,
+		},
+		{
+			desc: "execution not started",
+			kind: domain.KindCancel,
+			setup: func() {
+				s.mock.contextStorage.EXPECT().
+					Cancel(gomock.Any(), submissionID).Return(false, nil)
+			},
+			wantErr: event.NoErrSkipHandler,
+		},
+		{
+			desc: "success",
+			kind: domain.KindCancel,
+			setup: func() {
+				s.mock.contextStorage.EXPECT().
+					Cancel(gomock.Any(), submissionID).Return(true, nil)
+			},
+			wantErr:   nil,
+			cancelled: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			s.builder.cancelled = nil
+
+			tc.setup()
+
+			payload, _ := json.Marshal(event.SubmissionEvent{
+				ID:           uuid.New(),
+				SubmissionID: submissionID,
+				Kind:         tc.kind,
+			})
+
+			err := s.handler.CancelExecution(context.Background(), event.TopicSubmission, payload)
+			s.Equal(tc.wantErr, err)
+
+			if tc.cancelled {
+				s.Equal([]uuid.UUID{submissionID}, s.builder.cancelled)
+			} else {
+				s.Empty(s.builder.cancelled)
+			}
+		})
+	}
+}
+
+func (s *ExecEventHandlerSuite) TestNotifyTestResultDropsCancelled() {
+	submissionID := uuid.New()
+
+	s.mock.contextStorage.EXPECT().
+		Get(gomock.Any(), submissionID).Return(exec_module.ExecContext{Cancelled: true}, nil)
+	s.mock.contextStorage.EXPECT().
+		Delete(gomock.Any(), submissionID).Return(nil)
+	// Publisher should not be called.
+
+	payload, _ := json.Marshal(event.ExecEvent{ID: submissionID, Success: true})
+
+	err := s.handler.NotifyTestResult(context.Background(), event.TopicTest, payload)
+	s.NoError(err)
+}
+
+func (s *ExecEventHandlerSuite) TestEnqueueJobUsesRevision() {
+	submissionID := uuid.New()
+	revisionID := uuid.New()
+
+	revision := domain.TaskRevision{
+		ID:        revisionID,
+		Sections:  []domain.Section{{ID: uuid.New(), Type: domain.TypeScenario, Example: "{}"}},
+		Resources: []domain.Resource{{Name: "app", IsPrimary: true}},
+	}
+
+	s.mock.contextStorage.EXPECT().
+		Get(gomock.Any(), submissionID).Return(exec_module.ExecContext{RevisionID: &revisionID}, nil)
+	s.mock.revisionRepository.EXPECT().
+		FetchByID(gomock.Any(), revisionID).Return(revision, nil)
+	// Live sections and resources should not be used.
+	s.mock.jobQueue.EXPECT().
+		Append(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, job *exec_module.Job) {
+			s.Require().Len(job.Sections, 1)
+			s.Equal(revision.Sections[0].ID, job.Sections[0].ID)
+			s.Equal("{}", job.Sections[0].Example)
+			s.Require().Len(job.Resources, 1)
+			s.Equal("app", job.Resources[0].Name)
+		}).
+		Return(nil)
+	s.mock.eventPublisher.EXPECT().
+		Publish(gomock.Any(), event.TopicSubmission, gomock.Any()).Return(nil).Times(2)
+
+	payload, _ := json.Marshal(event.ExecEvent{ID: submissionID, Success: true})
+
+	err := s.handler.EnqueueJob(context.Background(), event.TopicBuild, payload)
+	s.NoError(err)
+}
@@ -18,6 +18,8 @@ type Section struct {
 	ID   uuid.UUID          `json:"id"`
 	Type domain.SectionType `json:"type"`
 	RPM  uint64             `json:"rpm"`
+	// Example is json-formatted request-response the section is tested with.
+	Example string `json:"example"`
 }
 
 type Submission struct {
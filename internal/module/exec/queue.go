@@ -2,6 +2,8 @@ package exec_module
 
 import (
 	"context"
+
+	"github.com/google/uuid"
 )
 
 //go:generate mockgen -source=queue.go -destination=../../../test/mocks/queue.go -package=mocks
@@ -9,3 +11,11 @@ import (
 type JobQueue interface {
 	Append(ctx context.Context, job *Job) error
 }
+
+// JobCanceller is optionally implemented by JobQueue
+// which is able to drop queued jobs or stop running ones.
+type JobCanceller interface {
+	// CancelJob cancels the job of the submission.
+	// It should not fail if there is no such job.
+	CancelJob(ctx context.Context, submissionID uuid.UUID) error
+}
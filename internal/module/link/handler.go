@@ -0,0 +1,75 @@
+package link_module
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/auth"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/status"
+	"github.com/pkg/errors"
+)
+
+type EventHandler struct {
+	linkRepository    domain.RepositoryLinkRepository
+	submissionUsecase domain.SubmissionUsecase
+}
+
+func NewEventHandler(lr domain.RepositoryLinkRepository, su domain.SubmissionUsecase) *EventHandler {
+	return &EventHandler{
+		linkRepository:    lr,
+		submissionUsecase: su,
+	}
+}
+
+func (h *EventHandler) Register(ctx context.Context, subscriber event.Subscriber) error {
+	if err := subscriber.Subscribe(ctx, event.TopicPush,
+		h.SubmitPush,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SubmitPush submits the pushed commit to every task linked with the branch.
+// Submissions are made on behalf of the user who linked it.
+func (h *EventHandler) SubmitPush(ctx context.Context, topic event.Topic, payload []byte) error {
+	var ev event.PushEvent
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		return errors.Wrap(err, "unmarshalling payload")
+	}
+
+	links, err := h.linkRepository.FetchAllByBranch(ctx, ev.Repository, ev.Branch)
+	if err != nil {
+		return errors.Wrap(err, "fetching repository links")
+	}
+
+	if len(links) == 0 {
+		return event.NoErrSkipHandler
+	}
+
+	for _, link := range links {
+		userCtx := auth.Inject(ctx, auth.Payload{UserID: link.UserID})
+
+		in := dto.SubmissionInput{
+			IDInput:    dto.IDInput{ID: link.TaskID.String()},
+			Repository: ev.Repository,
+			CommitHash: ev.CommitHash,
+		}
+
+		if _, err := h.submissionUsecase.Submit(userCtx, in); err != nil {
+			var sErr status.Error
+			if errors.As(err, &sErr) && sErr.StatusCode < 500 {
+				// Rejected by submission rules. e.g. Undone submission exists.
+				continue
+			}
+
+			return errors.Wrap(err, "submitting pushed commit")
+		}
+	}
+
+	return nil
+}
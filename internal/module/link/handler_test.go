@@ -0,0 +1,110 @@
+package link_module_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/auth"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/status"
+	link_module "github.com/oneee-playground/r2d2-api-server/internal/module/link"
+	"github.com/oneee-playground/r2d2-api-server/test/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestLinkEventHandlerSuite(t *testing.T) {
+	suite.Run(t, new(LinkEventHandlerSuite))
+}
+
+type LinkEventHandlerSuite struct {
+	suite.Suite
+
+	handler *link_module.EventHandler
+
+	ctl  *gomock.Controller
+	mock struct {
+		linkRepository    *mocks.MockRepositoryLinkRepository
+		submissionUsecase *mocks.MockSubmissionUsecase
+	}
+}
+
+func (s *LinkEventHandlerSuite) SetupTest() {
+	s.ctl = gomock.NewController(s.T())
+	s.mock.linkRepository = mocks.NewMockRepositoryLinkRepository(s.ctl)
+	s.mock.submissionUsecase = mocks.NewMockSubmissionUsecase(s.ctl)
+
+	s.handler = link_module.NewEventHandler(s.mock.linkRepository, s.mock.submissionUsecase)
+}
+
+func (s *LinkEventHandlerSuite) TestSubmitPush() {
+	ev := event.PushEvent{ID: uuid.New(), Repository: "user/repo", Branch: "main", CommitHash: "0123456789"}
+	payload, err := json.Marshal(ev)
+	s.Require().NoError(err)
+
+	links := []domain.RepositoryLink{
+		{ID: uuid.New(), UserID: uuid.New(), TaskID: uuid.New()},
+		{ID: uuid.New(), UserID: uuid.New(), TaskID: uuid.New()},
+	}
+
+	expectSubmit := func(link domain.RepositoryLink, err error) {
+		s.mock.submissionUsecase.EXPECT().
+			Submit(gomock.Any(), dto.SubmissionInput{
+				IDInput:    dto.IDInput{ID: link.TaskID.String()},
+				Repository: ev.Repository,
+				CommitHash: ev.CommitHash,
+			}).
+			DoAndReturn(func(ctx context.Context, _ dto.SubmissionInput) (*dto.IDOutput, error) {
+				s.Equal(link.UserID, auth.MustExtract(ctx).UserID)
+				return nil, err
+			})
+	}
+
+	testcases := []struct {
+		desc    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			desc: "submits every link",
+			setup: func() {
+				s.mock.linkRepository.EXPECT().
+					FetchAllByBranch(gomock.Any(), ev.Repository, ev.Branch).Return(links, nil)
+				expectSubmit(links[0], nil)
+				expectSubmit(links[1], nil)
+			},
+		},
+		{
+			desc: "rejected submission is skipped",
+			setup: func() {
+				s.mock.linkRepository.EXPECT().
+					FetchAllByBranch(gomock.Any(), ev.Repository, ev.Branch).Return(links, nil)
+				expectSubmit(links[0], status.NewErr(http.StatusConflict, "undone submission exists"))
+				expectSubmit(links[1], nil)
+			},
+		},
+		{
+			desc: "no links",
+			setup: func() {
+				s.mock.linkRepository.EXPECT().
+					FetchAllByBranch(gomock.Any(), ev.Repository, ev.Branch).Return(nil, nil)
+			},
+			wantErr: event.NoErrSkipHandler,
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			tc.setup()
+
+			err := s.handler.SubmitPush(context.Background(), event.TopicPush, payload)
+			s.True(errors.Is(err, tc.wantErr), err)
+		})
+	}
+}
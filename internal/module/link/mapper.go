@@ -0,0 +1,13 @@
+package link_module
+
+import (
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+)
+
+func toRepositoryLinkOutput(link domain.RepositoryLink) *dto.RepositoryLinkOutput {
+	return &dto.RepositoryLinkOutput{
+		Repository: link.Repository,
+		Branch:     link.Branch,
+	}
+}
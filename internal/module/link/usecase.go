@@ -0,0 +1,130 @@
+package link_module
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/auth"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/status"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/tx"
+	"github.com/pkg/errors"
+)
+
+var (
+	repositoryPattern = regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`)
+	branchPattern     = regexp.MustCompile(`^[A-Za-z0-9._/-]+$`)
+)
+
+type linkUsecase struct {
+	taskRepository domain.TaskRepository
+	linkRepository domain.RepositoryLinkRepository
+}
+
+var _ domain.RepositoryLinkUsecase = (*linkUsecase)(nil)
+
+func NewLinkUsecase(tr domain.TaskRepository, lr domain.RepositoryLinkRepository) *linkUsecase {
+	return &linkUsecase{
+		taskRepository: tr,
+		linkRepository: lr,
+	}
+}
+
+func (u *linkUsecase) GetLink(ctx context.Context, in dto.IDInput) (out *dto.RepositoryLinkOutput, err error) {
+	taskID := uuid.MustParse(in.ID)
+
+	info := auth.MustExtract(ctx)
+
+	link, err := u.linkRepository.FetchByUserAndTask(ctx, info.UserID, taskID)
+	if err != nil {
+		if errors.Is(err, domain.ErrRepositoryLinkNotFound) {
+			return nil, status.NewErr(http.StatusNotFound, err.Error())
+		}
+		return nil, errors.Wrap(err, "fetching repository link")
+	}
+
+	return toRepositoryLinkOutput(link), nil
+}
+
+func (u *linkUsecase) SetLink(ctx context.Context, in dto.RepositoryLinkInput) (err error) {
+	taskID := uuid.MustParse(in.ID)
+
+	if !repositoryPattern.MatchString(in.Repository) {
+		return status.NewErr(http.StatusBadRequest, "repository should be in form of owner/name")
+	}
+
+	if !branchPattern.MatchString(in.Branch) {
+		return status.NewErr(http.StatusBadRequest, "invalid branch name")
+	}
+
+	info := auth.MustExtract(ctx)
+
+	ctx, err = tx.NewAtomic(ctx, tx.AtomicOpts{
+		ReadOnly:    false,
+		DataSources: []any{u.taskRepository, u.linkRepository},
+	})
+	if err != nil {
+		return errors.Wrap(err, "starting atomic transaction")
+	}
+	defer tx.Evaluate(ctx, &err)
+
+	if _, err := u.taskRepository.FetchByID(ctx, taskID); err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return status.NewErr(http.StatusNotFound, err.Error())
+		}
+		return errors.Wrap(err, "fetching task")
+	}
+
+	link, err := u.linkRepository.FetchByUserAndTask(ctx, info.UserID, taskID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrRepositoryLinkNotFound) {
+			return errors.Wrap(err, "fetching repository link")
+		}
+
+		link = domain.RepositoryLink{
+			ID:         uuid.New(),
+			Repository: in.Repository,
+			Branch:     in.Branch,
+			UserID:     info.UserID,
+			TaskID:     taskID,
+		}
+
+		if err := u.linkRepository.Create(ctx, link); err != nil {
+			return errors.Wrap(err, "creating repository link")
+		}
+
+		return nil
+	}
+
+	link.Repository = in.Repository
+	link.Branch = in.Branch
+
+	if err := u.linkRepository.Update(ctx, link); err != nil {
+		return errors.Wrap(err, "updating repository link")
+	}
+
+	return nil
+}
+
+func (u *linkUsecase) DeleteLink(ctx context.Context, in dto.IDInput) (err error) {
+	taskID := uuid.MustParse(in.ID)
+
+	info := auth.MustExtract(ctx)
+
+	link, err := u.linkRepository.FetchByUserAndTask(ctx, info.UserID, taskID)
+	if err != nil {
+		if errors.Is(err, domain.ErrRepositoryLinkNotFound) {
+			return status.NewErr(http.StatusNotFound, err.Error())
+		}
+		return errors.Wrap(err, "fetching repository link")
+	}
+
+	if err := u.linkRepository.Delete(ctx, link.ID); err != nil {
+		return errors.Wrap(err, "deleting repository link")
+	}
+
+	return nil
+}
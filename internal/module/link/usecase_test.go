@@ -0,0 +1,124 @@
+package link_module_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/auth"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/status"
+	link_module "github.com/oneee-playground/r2d2-api-server/internal/module/link"
+	"github.com/oneee-playground/r2d2-api-server/test/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestLinkUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(LinkUsecaseSuite))
+}
+
+type LinkUsecaseSuite struct {
+	suite.Suite
+
+	usecase domain.RepositoryLinkUsecase
+
+	ctl  *gomock.Controller
+	mock struct {
+		taskRepository *mocks.MockTaskRepository
+		linkRepository *mocks.MockRepositoryLinkRepository
+	}
+}
+
+func (s *LinkUsecaseSuite) SetupTest() {
+	s.ctl = gomock.NewController(s.T())
+	s.mock.taskRepository = mocks.NewMockTaskRepository(s.ctl)
+	s.mock.linkRepository = mocks.NewMockRepositoryLinkRepository(s.ctl)
+
+	s.usecase = link_module.NewLinkUsecase(s.mock.taskRepository, s.mock.linkRepository)
+}
+
+func (s *LinkUsecaseSuite) TestSetLink() {
+	userID, taskID := uuid.New(), uuid.New()
+	existing := domain.RepositoryLink{ID: uuid.New(), Repository: "user/old", Branch: "main", UserID: userID, TaskID: taskID}
+
+	testcases := []struct {
+		desc     string
+		in       dto.RepositoryLinkInput
+		setup    func()
+		checkErr func(err error) bool
+	}{
+		{
+			desc: "create",
+			in:   dto.RepositoryLinkInput{Repository: "user/repo", Branch: "main"},
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), taskID).Return(domain.Task{ID: taskID}, nil)
+				s.mock.linkRepository.EXPECT().
+					FetchByUserAndTask(gomock.Any(), userID, taskID).
+					Return(domain.RepositoryLink{}, domain.ErrRepositoryLinkNotFound)
+				s.mock.linkRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, link domain.RepositoryLink) {
+						s.Equal("user/repo", link.Repository)
+						s.Equal(userID, link.UserID)
+					}).
+					Return(nil)
+			},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc: "update",
+			in:   dto.RepositoryLinkInput{Repository: "user/repo", Branch: "feature/x"},
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), taskID).Return(domain.Task{ID: taskID}, nil)
+				s.mock.linkRepository.EXPECT().
+					FetchByUserAndTask(gomock.Any(), userID, taskID).Return(existing, nil)
+				s.mock.linkRepository.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, link domain.RepositoryLink) {
+						s.Equal(existing.ID, link.ID)
+						s.Equal("feature/x", link.Branch)
+					}).
+					Return(nil)
+			},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc:  "invalid repository",
+			in:    dto.RepositoryLinkInput{Repository: "https://github.com/user/repo", Branch: "main"},
+			setup: func() {},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusBadRequest
+			},
+		},
+		{
+			desc: "task not found",
+			in:   dto.RepositoryLinkInput{Repository: "user/repo", Branch: "main"},
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), taskID).Return(domain.Task{}, domain.ErrTaskNotFound)
+			},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusNotFound
+			},
+		},
+	}
+
+	ctx := auth.Inject(context.Background(), auth.Payload{UserID: userID, Role: domain.RoleMember})
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			tc.setup()
+
+			tc.in.IDInput = dto.IDInput{ID: taskID.String()}
+
+			err := s.usecase.SetLink(ctx, tc.in)
+			s.True(tc.checkErr(err), err)
+		})
+	}
+}
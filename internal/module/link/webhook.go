@@ -0,0 +1,111 @@
+package link_module
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/status"
+	"github.com/pkg/errors"
+)
+
+type webhookUsecase struct {
+	secret         []byte
+	eventPublisher event.Publisher
+}
+
+var _ domain.WebhookUsecase = (*webhookUsecase)(nil)
+
+func NewWebhookUsecase(secret string, ep event.Publisher) *webhookUsecase {
+	return &webhookUsecase{
+		secret:         []byte(secret),
+		eventPublisher: ep,
+	}
+}
+
+type _pushPayload struct {
+	Ref     string `json:"ref"`
+	After   string `json:"after"`
+	Deleted bool   `json:"deleted"`
+
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// ReceiveGitHub only publishes the push, so that github gets the response in time.
+// Submissions are made by EventHandler.
+func (u *webhookUsecase) ReceiveGitHub(ctx context.Context, in dto.GitHubWebhookInput) (err error) {
+	if !validSignature(u.secret, in.Payload, in.Signature) {
+		return status.NewErr(http.StatusUnauthorized, "invalid signature")
+	}
+
+	if in.Event != "push" {
+		// Other events are acknowledged, but ignored.
+		return nil
+	}
+
+	var payload _pushPayload
+	if err := json.Unmarshal(in.Payload, &payload); err != nil {
+		return status.NewErr(http.StatusBadRequest, "malformed payload")
+	}
+
+	branch, ok := strings.CutPrefix(payload.Ref, "refs/heads/")
+	if !ok || payload.Deleted {
+		// Tags and deleted branches have nothing to submit.
+		return nil
+	}
+
+	// Github redelivers with the same delivery id.
+	// Using it as event id lets idempotent handlers skip it.
+	id, err := uuid.Parse(in.DeliveryID)
+	if err != nil {
+		id = uuid.New()
+	}
+
+	e := event.PushEvent{
+		ID:         id,
+		Repository: payload.Repository.FullName,
+		Branch:     branch,
+		CommitHash: payload.After,
+		Timestamp:  time.Now(),
+	}
+
+	if err := u.eventPublisher.Publish(ctx, event.TopicPush, e); err != nil {
+		return errors.Wrap(err, "publishing event")
+	}
+
+	return nil
+}
+
+// validSignature reports whether signature is HMAC-SHA256 of the payload.
+// Signature is in form of "sha256=<hex digest>". Nothing is valid without secret.
+func validSignature(secret, payload []byte, signature string) bool {
+	if len(secret) == 0 {
+		return false
+	}
+
+	digest, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+
+	return hmac.Equal(mac.Sum(nil), expected)
+}
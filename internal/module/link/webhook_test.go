@@ -0,0 +1,121 @@
+package link_module_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/status"
+	link_module "github.com/oneee-playground/r2d2-api-server/internal/module/link"
+	"github.com/oneee-playground/r2d2-api-server/test/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+const testSecret = "secret"
+
+func TestWebhookUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(WebhookUsecaseSuite))
+}
+
+type WebhookUsecaseSuite struct {
+	suite.Suite
+
+	usecase domain.WebhookUsecase
+
+	ctl  *gomock.Controller
+	mock struct {
+		eventPublisher *mocks.MockPublisher
+	}
+}
+
+func (s *WebhookUsecaseSuite) SetupTest() {
+	s.ctl = gomock.NewController(s.T())
+	s.mock.eventPublisher = mocks.NewMockPublisher(s.ctl)
+
+	s.usecase = link_module.NewWebhookUsecase(testSecret, s.mock.eventPublisher)
+}
+
+func sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *WebhookUsecaseSuite) TestReceiveGitHub() {
+	deliveryID := uuid.New()
+
+	push := []byte(`{"ref": "refs/heads/main", "after": "0123456789", "repository": {"full_name": "user/repo"}}`)
+	tag := []byte(`{"ref": "refs/tags/v1", "after": "0123456789", "repository": {"full_name": "user/repo"}}`)
+
+	testcases := []struct {
+		desc     string
+		in       dto.GitHubWebhookInput
+		setup    func()
+		checkErr func(err error) bool
+	}{
+		{
+			desc: "push",
+			in:   dto.GitHubWebhookInput{Event: "push", DeliveryID: deliveryID.String(), Signature: sign(push), Payload: push},
+			setup: func() {
+				s.mock.eventPublisher.EXPECT().
+					Publish(gomock.Any(), event.TopicPush, gomock.Any()).
+					Do(func(_ context.Context, _ event.Topic, e any) {
+						ev := e.(event.PushEvent)
+						s.Equal(deliveryID, ev.ID)
+						s.Equal("user/repo", ev.Repository)
+						s.Equal("main", ev.Branch)
+						s.Equal("0123456789", ev.CommitHash)
+					}).
+					Return(nil)
+			},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc:     "tag push",
+			in:       dto.GitHubWebhookInput{Event: "push", Signature: sign(tag), Payload: tag},
+			setup:    func() {},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc:     "other event",
+			in:       dto.GitHubWebhookInput{Event: "ping", Signature: sign(push), Payload: push},
+			setup:    func() {},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc:  "invalid signature",
+			in:    dto.GitHubWebhookInput{Event: "push", Signature: sign(tag), Payload: push},
+			setup: func() {},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusUnauthorized
+			},
+		},
+		{
+			desc:  "no signature",
+			in:    dto.GitHubWebhookInput{Event: "push", Payload: push},
+			setup: func() {},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusUnauthorized
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			tc.setup()
+
+			err := s.usecase.ReceiveGitHub(context.Background(), tc.in)
+			s.True(tc.checkErr(err), err)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package report_module
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	submission_module "github.com/oneee-playground/r2d2-api-server/internal/module/submission"
+	"github.com/pkg/errors"
+)
+
+// statusContext is shown as the name of the status on github.
+const statusContext = "r2d2"
+
+var commitStatuses = map[domain.EventKind]CommitStatus{
+	domain.KindBuildStart:  {State: StatePending, Description: "Building the image"},
+	domain.KindBuildFail:   {State: StateFailure, Description: "Build failed"},
+	domain.KindTestStart:   {State: StatePending, Description: "Testing"},
+	domain.KindTestSuccess: {State: StateSuccess, Description: "Test passed"},
+	domain.KindTestFail:    {State: StateFailure, Description: "Test failed"},
+}
+
+type EventHandler struct {
+	submissionRepository domain.SubmissionRepository
+	userRepository       domain.UserRepository
+
+	reporter StatusReporter
+	// publicURL is base url of the events page. Statuses have no link if it is empty.
+	publicURL string
+}
+
+func NewEventHandler(
+	sr domain.SubmissionRepository, ur domain.UserRepository,
+	r StatusReporter, publicURL string,
+) *EventHandler {
+	return &EventHandler{
+		submissionRepository: sr,
+		userRepository:       ur,
+		reporter:             r,
+		publicURL:            publicURL,
+	}
+}
+
+func (h *EventHandler) Register(ctx context.Context, subscriber event.Subscriber) error {
+	if err := subscriber.Subscribe(ctx, event.TopicSubmission,
+		h.ReportCommitStatus,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ReportCommitStatus reports progress of the submission to the commit on github.
+// It is only reported if the user has installed github app on the repository.
+func (h *EventHandler) ReportCommitStatus(ctx context.Context, topic event.Topic, payload []byte) error {
+	var ev event.SubmissionEvent
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		return errors.Wrap(err, "unmarshalling payload")
+	}
+
+	status, ok := commitStatuses[ev.Kind]
+	if !ok {
+		return event.NoErrSkipHandler
+	}
+
+	user, err := h.userRepository.FetchByID(ctx, ev.UserID)
+	if err != nil {
+		return errors.Wrap(err, "fetching user")
+	}
+
+	if user.InstallationID == nil {
+		return event.NoErrSkipHandler
+	}
+
+	submission, err := h.submissionRepository.FetchByID(ctx, ev.SubmissionID)
+	if err != nil {
+		return errors.Wrap(err, "fetching submission")
+	}
+
+	status.Context = statusContext
+	if h.publicURL != "" {
+		status.TargetURL = fmt.Sprintf("%s/tasks/%s/submissions/%s/events",
+			h.publicURL, submission.TaskID, submission.ID,
+		)
+	}
+
+	err = h.reporter.ReportStatus(ctx, *user.InstallationID, submission.Repository, submission.CommitHash, status)
+	if err != nil {
+		if errors.Is(err, submission_module.ErrRepositoryNotFound) {
+			// App is not installed on the repository.
+			return event.NoErrSkipHandler
+		}
+		return errors.Wrap(err, "reporting commit status")
+	}
+
+	return nil
+}
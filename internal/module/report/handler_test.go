@@ -0,0 +1,128 @@
+package report_module_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	report_module "github.com/oneee-playground/r2d2-api-server/internal/module/report"
+	submission_module "github.com/oneee-playground/r2d2-api-server/internal/module/submission"
+	"github.com/oneee-playground/r2d2-api-server/test/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestReportEventHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ReportEventHandlerSuite))
+}
+
+type ReportEventHandlerSuite struct {
+	suite.Suite
+
+	handler *report_module.EventHandler
+
+	ctl  *gomock.Controller
+	mock struct {
+		submissionRepository *mocks.MockSubmissionRepository
+		userRepository       *mocks.MockUserRepository
+		reporter             *mocks.MockStatusReporter
+	}
+}
+
+func (s *ReportEventHandlerSuite) SetupTest() {
+	s.ctl = gomock.NewController(s.T())
+	s.mock.submissionRepository = mocks.NewMockSubmissionRepository(s.ctl)
+	s.mock.userRepository = mocks.NewMockUserRepository(s.ctl)
+	s.mock.reporter = mocks.NewMockStatusReporter(s.ctl)
+
+	s.handler = report_module.NewEventHandler(
+		s.mock.submissionRepository, s.mock.userRepository,
+		s.mock.reporter, "https://r2d2.example.com",
+	)
+}
+
+func (s *ReportEventHandlerSuite) TestReportCommitStatus() {
+	installationID := int64(1)
+	user := domain.User{ID: uuid.New(), InstallationID: &installationID}
+	submission := domain.Submission{
+		ID:         uuid.New(),
+		TaskID:     uuid.New(),
+		Repository: "user/repo",
+		CommitHash: "0123456789",
+	}
+
+	testcases := []struct {
+		desc    string
+		kind    domain.EventKind
+		setup   func()
+		wantErr error
+	}{
+		{
+			desc: "test success",
+			kind: domain.KindTestSuccess,
+			setup: func() {
+				s.mock.userRepository.EXPECT().
+					FetchByID(gomock.Any(), user.ID).Return(user, nil)
+				s.mock.submissionRepository.EXPECT().
+					FetchByID(gomock.Any(), submission.ID).Return(submission, nil)
+				s.mock.reporter.EXPECT().
+					ReportStatus(gomock.Any(), installationID, submission.Repository, submission.CommitHash, gomock.Any()).
+					Do(func(_ context.Context, _ int64, _, _ string, status report_module.CommitStatus) {
+						s.Equal(report_module.StateSuccess, status.State)
+						s.Contains(status.TargetURL, submission.ID.String())
+					}).
+					Return(nil)
+			},
+		},
+		{
+			desc:    "unmapped kind",
+			kind:    domain.KindSubmit,
+			setup:   func() {},
+			wantErr: event.NoErrSkipHandler,
+		},
+		{
+			desc: "user without installation",
+			kind: domain.KindBuildStart,
+			setup: func() {
+				s.mock.userRepository.EXPECT().
+					FetchByID(gomock.Any(), user.ID).Return(domain.User{ID: user.ID}, nil)
+			},
+			wantErr: event.NoErrSkipHandler,
+		},
+		{
+			desc: "repository without the app",
+			kind: domain.KindBuildFail,
+			setup: func() {
+				s.mock.userRepository.EXPECT().
+					FetchByID(gomock.Any(), user.ID).Return(user, nil)
+				s.mock.submissionRepository.EXPECT().
+					FetchByID(gomock.Any(), submission.ID).Return(submission, nil)
+				s.mock.reporter.EXPECT().
+					ReportStatus(gomock.Any(), installationID, submission.Repository, submission.CommitHash, gomock.Any()).
+					Return(submission_module.ErrRepositoryNotFound)
+			},
+			wantErr: event.NoErrSkipHandler,
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			tc.setup()
+
+			payload, err := json.Marshal(event.SubmissionEvent{
+				ID:           uuid.New(),
+				SubmissionID: submission.ID,
+				UserID:       user.ID,
+				Kind:         tc.kind,
+			})
+			s.Require().NoError(err)
+
+			err = s.handler.ReportCommitStatus(context.Background(), event.TopicSubmission, payload)
+			s.True(errors.Is(err, tc.wantErr), err)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package report_module
+
+import (
+	"context"
+)
+
+//go:generate mockgen -source=reporter.go -destination=../../../test/mocks/reporter.go -package=mocks
+
+type CommitState string
+
+const (
+	StatePending CommitState = "pending"
+	StateSuccess CommitState = "success"
+	StateFailure CommitState = "failure"
+	StateError   CommitState = "error"
+)
+
+// CommitStatus is shown next to the commit on github.
+type CommitStatus struct {
+	State       CommitState
+	Description string
+	// TargetURL is linked from the status. It can be empty.
+	TargetURL string
+	// Context distinguishes statuses from other services.
+	Context string
+}
+
+type StatusReporter interface {
+	// ReportStatus creates status of the commit using the installation.
+	// It returns submission_module.ErrRepositoryNotFound if the installation cannot access the repository.
+	ReportStatus(ctx context.Context, installationID int64, fullName, sha string, status CommitStatus) error
+}
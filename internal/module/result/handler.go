@@ -0,0 +1,140 @@
+package result_module
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/tx"
+	"github.com/pkg/errors"
+)
+
+type EventHandler struct {
+	submissionRepository domain.SubmissionRepository
+	resultRepository     domain.ResultRepository
+}
+
+func NewEventHandler(sr domain.SubmissionRepository, rr domain.ResultRepository) *EventHandler {
+	return &EventHandler{
+		submissionRepository: sr,
+		resultRepository:     rr,
+	}
+}
+
+func (h *EventHandler) Register(ctx context.Context, subscriber event.Subscriber) error {
+	if err := subscriber.Subscribe(ctx, event.TopicTest,
+		h.StoreResults,
+	); err != nil {
+		return err
+	}
+
+	if err := subscriber.Subscribe(ctx, event.TopicSubmission,
+		h.ScoreSubmission,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// StoreResults saves results of each section.
+// It doesn't depend on exec context, since it could be deleted before redelivery.
+func (h *EventHandler) StoreResults(ctx context.Context, topic event.Topic, payload []byte) (err error) {
+	var ev event.ExecEvent
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		return errors.Wrap(err, "unmarshalling payload")
+	}
+
+	// Runners not reporting results per section are allowed.
+	if len(ev.Results) == 0 {
+		return event.NoErrSkipHandler
+	}
+
+	ctx, err = tx.NewAtomic(ctx, tx.AtomicOpts{
+		ReadOnly: false,
+		DataSources: []any{
+			h.submissionRepository,
+			h.resultRepository,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "starting atomic transaction")
+	}
+	defer tx.Evaluate(ctx, &err)
+
+	submission, err := h.submissionRepository.FetchByID(ctx, ev.ID)
+	if err != nil {
+		return errors.Wrap(err, "fetching submission")
+	}
+
+	// Results of cancelled submissions are dropped.
+	if submission.IsCancelled {
+		return event.NoErrSkipHandler
+	}
+
+	results := make([]domain.Result, len(ev.Results))
+	for idx, result := range ev.Results {
+		results[idx] = domain.Result{
+			ID:             uuid.New(),
+			Passed:         result.Passed,
+			Error:          result.Error,
+			TotalRequests:  result.TotalRequests,
+			FailedRequests: result.FailedRequests,
+			P50:            result.P50,
+			P95:            result.P95,
+			P99:            result.P99,
+			SubmissionID:   ev.ID,
+			SectionID:      result.SectionID,
+		}
+	}
+
+	if err := h.resultRepository.CreateBulk(ctx, results); err != nil {
+		return errors.Wrap(err, "creating results")
+	}
+
+	// Results stored on redelivery could arrive after the submission is scored.
+	if submission.Score != nil {
+		return h.updateScore(ctx, submission)
+	}
+
+	return nil
+}
+
+// ScoreSubmission saves total score of the submission when its test is finished.
+// It relies on results stored by StoreResults beforehand.
+func (h *EventHandler) ScoreSubmission(ctx context.Context, topic event.Topic, payload []byte) error {
+	var ev event.SubmissionEvent
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		return errors.Wrap(err, "unmarshalling payload")
+	}
+
+	if ev.Kind != domain.KindTestSuccess && ev.Kind != domain.KindTestFail {
+		return event.NoErrSkipHandler
+	}
+
+	submission, err := h.submissionRepository.FetchByID(ctx, ev.SubmissionID)
+	if err != nil {
+		return errors.Wrap(err, "fetching submission")
+	}
+
+	return h.updateScore(ctx, submission)
+}
+
+// updateScore saves total score of the submission from its stored results.
+func (h *EventHandler) updateScore(ctx context.Context, submission domain.Submission) error {
+	results, err := h.resultRepository.FetchAllBySubmissionID(ctx, submission.ID)
+	if err != nil {
+		return errors.Wrap(err, "fetching results")
+	}
+
+	score := totalScore(results)
+	submission.Score = &score
+
+	if err := h.submissionRepository.Update(ctx, submission); err != nil {
+		return errors.Wrap(err, "updating submission")
+	}
+
+	return nil
+}
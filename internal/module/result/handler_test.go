@@ -0,0 +1,131 @@
+package result_module_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
+	result_module "github.com/oneee-playground/r2d2-api-server/internal/module/result"
+	"github.com/oneee-playground/r2d2-api-server/test/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestResultEventHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ResultEventHandlerSuite))
+}
+
+type ResultEventHandlerSuite struct {
+	suite.Suite
+
+	handler *result_module.EventHandler
+
+	ctl  *gomock.Controller
+	mock struct {
+		submissionRepository *mocks.MockSubmissionRepository
+		resultRepository     *mocks.MockResultRepository
+	}
+}
+
+func (s *ResultEventHandlerSuite) SetupTest() {
+	s.ctl = gomock.NewController(s.T())
+	s.mock.submissionRepository = mocks.NewMockSubmissionRepository(s.ctl)
+	s.mock.resultRepository = mocks.NewMockResultRepository(s.ctl)
+
+	s.handler = result_module.NewEventHandler(s.mock.submissionRepository, s.mock.resultRepository)
+}
+
+func (s *ResultEventHandlerSuite) TestStoreResults() {
+	submission := domain.Submission{ID: uuid.New()}
+
+	testcases := []struct {
+		desc    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			desc: "success",
+			setup: func() {
+				s.mock.submissionRepository.EXPECT().
+					FetchByID(gomock.Any(), submission.ID).Return(submission, nil)
+				s.mock.resultRepository.EXPECT().
+					CreateBulk(gomock.Any(), gomock.Len(1)).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			desc: "cancelled",
+			setup: func() {
+				cancelled := submission
+				cancelled.IsCancelled = true
+
+				s.mock.submissionRepository.EXPECT().
+					FetchByID(gomock.Any(), submission.ID).Return(cancelled, nil)
+			},
+			wantErr: event.NoErrSkipHandler,
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			tc.setup()
+
+			payload, _ := json.Marshal(event.ExecEvent{
+				ID:      submission.ID,
+				Success: true,
+				Results: []event.SectionResult{{SectionID: uuid.New(), Passed: true}},
+			})
+
+			err := s.handler.StoreResults(context.Background(), event.TopicTest, payload)
+			s.ErrorIs(err, tc.wantErr)
+		})
+	}
+}
+
+func (s *ResultEventHandlerSuite) TestStoreResultsRedelivered() {
+	section := domain.Section{ID: uuid.New(), Type: domain.TypeScenario}
+	submission := domain.Submission{ID: uuid.New()}
+
+	payload, _ := json.Marshal(event.ExecEvent{
+		ID:      submission.ID,
+		Success: true,
+		Results: []event.SectionResult{{SectionID: section.ID, Passed: true}},
+	})
+
+	// First delivery fails to store results.
+	s.mock.submissionRepository.EXPECT().
+		FetchByID(gomock.Any(), submission.ID).Return(submission, nil)
+	s.mock.resultRepository.EXPECT().
+		CreateBulk(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+
+	err := s.handler.StoreResults(context.Background(), event.TopicTest, payload)
+	s.Error(err)
+
+	// Meanwhile, the submission is scored without results.
+	zero := uint64(0)
+	scored := submission
+	scored.Score = &zero
+
+	// Redelivery stores results, and scores it again.
+	s.mock.submissionRepository.EXPECT().
+		FetchByID(gomock.Any(), submission.ID).Return(scored, nil)
+	s.mock.resultRepository.EXPECT().
+		CreateBulk(gomock.Any(), gomock.Len(1)).Return(nil)
+	s.mock.resultRepository.EXPECT().
+		FetchAllBySubmissionID(gomock.Any(), submission.ID).
+		Return([]domain.Result{{Passed: true, SectionID: section.ID, Section: &section}}, nil)
+	s.mock.submissionRepository.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, submission domain.Submission) {
+			s.Require().NotNil(submission.Score)
+			s.NotZero(*submission.Score)
+		}).
+		Return(nil)
+
+	err = s.handler.StoreResults(context.Background(), event.TopicTest, payload)
+	s.NoError(err)
+}
@@ -0,0 +1,33 @@
+package result_module
+
+import (
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+)
+
+func toResultListOutput(results []domain.Result) *dto.ResultListOutput {
+	out := make(dto.ResultListOutput, len(results))
+	for i, result := range results {
+		out[i] = dto.ResultListElem{
+			SectionID:      result.SectionID.String(),
+			Passed:         result.Passed,
+			Error:          result.Error,
+			TotalRequests:  result.TotalRequests,
+			FailedRequests: result.FailedRequests,
+			Latency: dto.LatencyInfo{
+				P50: result.P50.Milliseconds(),
+				P95: result.P95.Milliseconds(),
+				P99: result.P99.Milliseconds(),
+			},
+		}
+
+		if result.Section != nil {
+			out[i].SectionTitle = result.Section.Title
+			out[i].SectionIndex = result.Section.Index
+			out[i].SectionType = string(result.Section.Type)
+			out[i].Score = scoreOf(result.Section.Type, result)
+		}
+	}
+
+	return &out
+}
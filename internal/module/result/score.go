@@ -0,0 +1,63 @@
+package result_module
+
+import (
+	"time"
+
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+)
+
+const (
+	// scenarioPoints is given for passing a scenario section.
+	scenarioPoints = 100
+	// loadPoints is the maximum points of a load section.
+	// Half of it is for throughput, and the other half is for latency.
+	loadPoints = 100
+
+	// latencyBaseline is p95 latency that gets full latency points.
+	// Slower responses get points in inverse proportion to it.
+	latencyBaseline = 100 * time.Millisecond
+)
+
+// scoreOf calculates points of the section from its result.
+// Failed sections get no points.
+func scoreOf(sectionType domain.SectionType, result domain.Result) uint64 {
+	if !result.Passed {
+		return 0
+	}
+
+	switch sectionType {
+	case domain.TypeScenario:
+		return scenarioPoints
+	case domain.TypeLoad:
+		if result.TotalRequests == 0 {
+			return 0
+		}
+
+		succeeded := result.TotalRequests - result.FailedRequests
+		throughput := float64(succeeded) / float64(result.TotalRequests)
+
+		latency := 1.0
+		if result.P95 > latencyBaseline {
+			latency = float64(latencyBaseline) / float64(result.P95)
+		}
+
+		return uint64((throughput + latency) / 2 * loadPoints)
+	}
+
+	return 0
+}
+
+// totalScore sums up points of all results. Results should include Section field.
+// Results of unknown sections get no points.
+func totalScore(results []domain.Result) uint64 {
+	var total uint64
+	for _, result := range results {
+		if result.Section == nil {
+			continue
+		}
+
+		total += scoreOf(result.Section.Type, result)
+	}
+
+	return total
+}
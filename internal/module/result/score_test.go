@@ -0,0 +1,59 @@
+package result_module
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreOf(t *testing.T) {
+	testcases := []struct {
+		desc        string
+		sectionType domain.SectionType
+		result      domain.Result
+		expected    uint64
+	}{
+		{
+			desc:        "failed section",
+			sectionType: domain.TypeScenario,
+			result:      domain.Result{Passed: false},
+			expected:    0,
+		},
+		{
+			desc:        "passed scenario",
+			sectionType: domain.TypeScenario,
+			result:      domain.Result{Passed: true},
+			expected:    scenarioPoints,
+		},
+		{
+			desc:        "fast load without failure",
+			sectionType: domain.TypeLoad,
+			result:      domain.Result{Passed: true, TotalRequests: 100, P95: latencyBaseline},
+			expected:    loadPoints,
+		},
+		{
+			desc:        "slow load with failures",
+			sectionType: domain.TypeLoad,
+			result: domain.Result{
+				Passed: true, TotalRequests: 100, FailedRequests: 10,
+				P95: 2 * latencyBaseline,
+			},
+			// (0.9 + 0.5) / 2 * 100
+			expected: 70,
+		},
+		{
+			desc:        "load without requests",
+			sectionType: domain.TypeLoad,
+			result:      domain.Result{Passed: true, P95: time.Second},
+			expected:    0,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, scoreOf(tc.sectionType, tc.result))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package result_module
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/status"
+	"github.com/pkg/errors"
+)
+
+type resultUsecase struct {
+	submissionRepository domain.SubmissionRepository
+	resultRepository     domain.ResultRepository
+}
+
+var _ domain.ResultUsecase = (*resultUsecase)(nil)
+
+func NewResultUsecase(sr domain.SubmissionRepository, rr domain.ResultRepository) *resultUsecase {
+	return &resultUsecase{
+		submissionRepository: sr,
+		resultRepository:     rr,
+	}
+}
+
+func (u *resultUsecase) GetAllFromSubmission(ctx context.Context, in dto.SubmissionIDInput) (out *dto.ResultListOutput, err error) {
+	taskID := uuid.MustParse(in.TaskID)
+	submissionID := uuid.MustParse(in.SubmissionID)
+
+	submission, err := u.submissionRepository.FetchByID(ctx, submissionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSubmissionNotFound) {
+			return nil, status.NewErr(http.StatusNotFound, err.Error())
+		}
+
+		return nil, errors.Wrap(err, "fetching submission")
+	}
+
+	if submission.TaskID != taskID {
+		return nil, status.NewErr(http.StatusNotFound, domain.ErrSubmissionNotFound.Error())
+	}
+
+	results, err := u.resultRepository.FetchAllBySubmissionID(ctx, submissionID)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching results")
+	}
+
+	return toResultListOutput(results), nil
+}
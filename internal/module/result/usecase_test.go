@@ -0,0 +1,120 @@
+package result_module_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/status"
+	result_module "github.com/oneee-playground/r2d2-api-server/internal/module/result"
+	"github.com/oneee-playground/r2d2-api-server/test/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestResultUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(ResultUsecaseSuite))
+}
+
+type ResultUsecaseSuite struct {
+	suite.Suite
+
+	usecase domain.ResultUsecase
+
+	ctl  *gomock.Controller
+	mock struct {
+		submissionRepository *mocks.MockSubmissionRepository
+		resultRepository     *mocks.MockResultRepository
+	}
+}
+
+func (s *ResultUsecaseSuite) SetupTest() {
+	s.ctl = gomock.NewController(s.T())
+	s.mock.submissionRepository = mocks.NewMockSubmissionRepository(s.ctl)
+	s.mock.resultRepository = mocks.NewMockResultRepository(s.ctl)
+
+	s.usecase = result_module.NewResultUsecase(s.mock.submissionRepository, s.mock.resultRepository)
+}
+
+func (s *ResultUsecaseSuite) TestGetAllFromSubmission() {
+	taskID := uuid.New()
+	submission := domain.Submission{ID: uuid.New(), TaskID: taskID}
+
+	testResults := []domain.Result{
+		{
+			Passed:        true,
+			TotalRequests: 1,
+			P50:           10 * time.Millisecond,
+			P95:           10 * time.Millisecond,
+			P99:           10 * time.Millisecond,
+			Section:       &domain.Section{Title: "scenario", Index: 0, Type: domain.TypeScenario},
+		},
+	}
+
+	testcases := []struct {
+		desc     string
+		taskID   uuid.UUID
+		setup    func()
+		checkErr func(err error) bool
+	}{
+		{
+			desc:   "success",
+			taskID: taskID,
+			setup: func() {
+				s.mock.submissionRepository.EXPECT().
+					FetchByID(gomock.Any(), gomock.Any()).Return(submission, nil)
+				s.mock.resultRepository.EXPECT().
+					FetchAllBySubmissionID(gomock.Any(), submission.ID).Return(testResults, nil)
+			},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc:   "submission not found",
+			taskID: taskID,
+			setup: func() {
+				s.mock.submissionRepository.EXPECT().
+					FetchByID(gomock.Any(), gomock.Any()).Return(domain.Submission{}, domain.ErrSubmissionNotFound)
+			},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusNotFound
+			},
+		},
+		{
+			desc:   "submission of another task",
+			taskID: uuid.New(),
+			setup: func() {
+				s.mock.submissionRepository.EXPECT().
+					FetchByID(gomock.Any(), gomock.Any()).Return(submission, nil)
+			},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusNotFound
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			ctx := context.Background()
+
+			tc.setup()
+
+			in := dto.SubmissionIDInput{
+				TaskID:       tc.taskID.String(),
+				SubmissionID: submission.ID.String(),
+			}
+
+			out, err := s.usecase.GetAllFromSubmission(ctx, in)
+			s.True(tc.checkErr(err))
+			if err == nil {
+				s.Len(*out, len(testResults))
+				s.Equal(int64(10), (*out)[0].Latency.P99)
+			}
+		})
+	}
+}
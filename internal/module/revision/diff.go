@@ -0,0 +1,115 @@
+package revision_module
+
+import (
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+)
+
+const (
+	kindAdded   = "ADDED"
+	kindRemoved = "REMOVED"
+	kindChanged = "CHANGED"
+)
+
+// diffSections compares sections by its ID.
+// Entries follow the order of sections in to, and removed ones come last.
+func diffSections(from, to []domain.Section) []dto.DiffEntry {
+	prev := make(map[uuid.UUID]domain.Section, len(from))
+	for _, section := range from {
+		prev[section.ID] = section
+	}
+
+	entries := make([]dto.DiffEntry, 0)
+
+	for _, section := range to {
+		old, ok := prev[section.ID]
+		if !ok {
+			entries = append(entries, dto.DiffEntry{Kind: kindAdded, Target: section.ID.String()})
+			continue
+		}
+		delete(prev, section.ID)
+
+		changes := compare(
+			field("title", old.Title, section.Title),
+			field("description", old.Description, section.Description),
+			field("index", old.Index, section.Index),
+			field("type", old.Type, section.Type),
+			field("rpm", old.RPM, section.RPM),
+			field("example", old.Example, section.Example),
+		)
+
+		if len(changes) > 0 {
+			entries = append(entries, dto.DiffEntry{Kind: kindChanged, Target: section.ID.String(), Changes: changes})
+		}
+	}
+
+	for _, section := range from {
+		if _, ok := prev[section.ID]; ok {
+			entries = append(entries, dto.DiffEntry{Kind: kindRemoved, Target: section.ID.String()})
+		}
+	}
+
+	return entries
+}
+
+// diffResources compares resources by its name.
+// Entries follow the order of resources in to, and removed ones come last.
+func diffResources(from, to []domain.Resource) []dto.DiffEntry {
+	prev := make(map[string]domain.Resource, len(from))
+	for _, resource := range from {
+		prev[resource.Name] = resource
+	}
+
+	entries := make([]dto.DiffEntry, 0)
+
+	for _, resource := range to {
+		old, ok := prev[resource.Name]
+		if !ok {
+			entries = append(entries, dto.DiffEntry{Kind: kindAdded, Target: resource.Name})
+			continue
+		}
+		delete(prev, resource.Name)
+
+		changes := compare(
+			field("image", old.Image, resource.Image),
+			field("port", old.Port, resource.Port),
+			field("cpu", old.CPU, resource.CPU),
+			field("memory", old.Memory, resource.Memory),
+			field("isPrimary", old.IsPrimary, resource.IsPrimary),
+		)
+
+		if len(changes) > 0 {
+			entries = append(entries, dto.DiffEntry{Kind: kindChanged, Target: resource.Name, Changes: changes})
+		}
+	}
+
+	for _, resource := range from {
+		if _, ok := prev[resource.Name]; ok {
+			entries = append(entries, dto.DiffEntry{Kind: kindRemoved, Target: resource.Name})
+		}
+	}
+
+	return entries
+}
+
+// field returns change of the field, or nil if not changed.
+func field[T comparable](name string, from, to T) *dto.FieldChange {
+	if from == to {
+		return nil
+	}
+
+	return &dto.FieldChange{Field: name, From: from, To: to}
+}
+
+// compare collects non-nil changes.
+func compare(changes ...*dto.FieldChange) []dto.FieldChange {
+	collected := make([]dto.FieldChange, 0)
+	for _, change := range changes {
+		if change != nil {
+			collected = append(collected, *change)
+		}
+	}
+
+	return collected
+}
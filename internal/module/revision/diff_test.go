@@ -0,0 +1,58 @@
+package revision_module
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSections(t *testing.T) {
+	kept := domain.Section{ID: uuid.New(), Title: "kept", Type: domain.TypeScenario}
+	changed := domain.Section{ID: uuid.New(), Title: "load", Index: 1, Type: domain.TypeLoad, RPM: 60}
+	removed := domain.Section{ID: uuid.New(), Title: "removed", Index: 2}
+	added := domain.Section{ID: uuid.New(), Title: "added", Index: 2}
+
+	changedAfter := changed
+	changedAfter.RPM = 120
+
+	from := []domain.Section{kept, changed, removed}
+	to := []domain.Section{kept, changedAfter, added}
+
+	expected := []dto.DiffEntry{
+		{
+			Kind:    kindChanged,
+			Target:  changed.ID.String(),
+			Changes: []dto.FieldChange{{Field: "rpm", From: uint64(60), To: uint64(120)}},
+		},
+		{Kind: kindAdded, Target: added.ID.String()},
+		{Kind: kindRemoved, Target: removed.ID.String()},
+	}
+
+	assert.Equal(t, expected, diffSections(from, to))
+}
+
+func TestDiffResources(t *testing.T) {
+	from := []domain.Resource{
+		{Name: "app", Image: "app:1", Port: 8080, IsPrimary: true},
+		{Name: "cache", Image: "redis:7", Port: 6379},
+	}
+	to := []domain.Resource{
+		{Name: "app", Image: "app:2", Port: 8080, IsPrimary: true},
+		{Name: "db", Image: "mysql:8", Port: 3306},
+	}
+
+	expected := []dto.DiffEntry{
+		{
+			Kind:    kindChanged,
+			Target:  "app",
+			Changes: []dto.FieldChange{{Field: "image", From: "app:1", To: "app:2"}},
+		},
+		{Kind: kindAdded, Target: "db"},
+		{Kind: kindRemoved, Target: "cache"},
+	}
+
+	assert.Equal(t, expected, diffResources(from, to))
+}
@@ -0,0 +1,21 @@
+package revision_module
+
+import (
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+)
+
+func toRevisionListOutput(revisions []domain.TaskRevision) *dto.RevisionListOutput {
+	out := make(dto.RevisionListOutput, len(revisions))
+	for i, revision := range revisions {
+		out[i] = dto.RevisionListElem{
+			ID:            revision.ID.String(),
+			Number:        revision.Number,
+			CreatedAt:     revision.CreatedAt,
+			SectionCount:  len(revision.Sections),
+			ResourceCount: len(revision.Resources),
+		}
+	}
+
+	return &out
+}
@@ -0,0 +1,87 @@
+package revision_module
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/status"
+	"github.com/pkg/errors"
+)
+
+type revisionUsecase struct {
+	taskRepository     domain.TaskRepository
+	revisionRepository domain.TaskRevisionRepository
+}
+
+var _ domain.RevisionUsecase = (*revisionUsecase)(nil)
+
+func NewRevisionUsecase(tr domain.TaskRepository, rr domain.TaskRevisionRepository) *revisionUsecase {
+	return &revisionUsecase{
+		taskRepository:     tr,
+		revisionRepository: rr,
+	}
+}
+
+func (u *revisionUsecase) GetList(ctx context.Context, in dto.IDInput) (out *dto.RevisionListOutput, err error) {
+	taskID := uuid.MustParse(in.ID)
+
+	exists, err := u.taskRepository.ExistsByID(ctx, taskID)
+	if err != nil {
+		return nil, errors.Wrap(err, "checking task exists")
+	}
+
+	if !exists {
+		return nil, status.NewErr(http.StatusNotFound, domain.ErrTaskNotFound.Error())
+	}
+
+	revisions, err := u.revisionRepository.FetchAllByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching revisions")
+	}
+
+	return toRevisionListOutput(revisions), nil
+}
+
+func (u *revisionUsecase) Diff(ctx context.Context, in dto.RevisionDiffInput) (out *dto.RevisionDiffOutput, err error) {
+	taskID := uuid.MustParse(in.ID)
+
+	from, err := u.fetchRevision(ctx, taskID, uuid.MustParse(in.From))
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := u.fetchRevision(ctx, taskID, uuid.MustParse(in.To))
+	if err != nil {
+		return nil, err
+	}
+
+	out = &dto.RevisionDiffOutput{
+		From:      from.Number,
+		To:        to.Number,
+		Sections:  diffSections(from.Sections, to.Sections),
+		Resources: diffResources(from.Resources, to.Resources),
+	}
+
+	return out, nil
+}
+
+// fetchRevision fetches revision which belongs to the task.
+func (u *revisionUsecase) fetchRevision(ctx context.Context, taskID, id uuid.UUID) (domain.TaskRevision, error) {
+	revision, err := u.revisionRepository.FetchByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrRevisionNotFound) {
+			return domain.TaskRevision{}, status.NewErr(http.StatusNotFound, err.Error())
+		}
+
+		return domain.TaskRevision{}, errors.Wrap(err, "fetching revision")
+	}
+
+	if revision.TaskID != taskID {
+		return domain.TaskRevision{}, status.NewErr(http.StatusNotFound, domain.ErrRevisionNotFound.Error())
+	}
+
+	return revision, nil
+}
@@ -0,0 +1,37 @@
+package submission_module
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/pkg/errors"
+)
+
+// matchApprovalRule reports the rule of the policy which approves the submission.
+// It returns false if the submission should be decided by admins.
+func (u *submissionUsecase) matchApprovalRule(
+	ctx context.Context, policy domain.ApprovalPolicy, submission domain.Submission, source SourceRepository,
+) (domain.ApprovalRule, bool, error) {
+	switch policy.Rule {
+	case domain.RuleAll:
+		return policy.Rule, true, nil
+	case domain.RuleApprovedBefore:
+		approved, err := u.submissionRepository.ApprovedExists(ctx, submission.UserID)
+		if err != nil {
+			return "", false, errors.Wrap(err, "checking if approved submission exists")
+		}
+
+		return policy.Rule, approved, nil
+	case domain.RuleAllowedOrgs:
+		allowed := slices.ContainsFunc(policy.AllowedOrgs, func(org string) bool {
+			return strings.EqualFold(org, source.Owner)
+		})
+
+		return policy.Rule, allowed, nil
+	}
+
+	// Manual, or tasks without a policy.
+	return "", false, nil
+}
@@ -0,0 +1,66 @@
+package submission_module
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+)
+
+//go:generate mockgen -source=limiter.go -destination=../../../test/mocks/limiter.go -package=mocks
+
+// Limit allows at most Count submissions in Window.
+type Limit struct {
+	Key    string
+	Count  uint64
+	Window time.Duration
+}
+
+type RateLimiter interface {
+	// Take counts a submission on every limit if none of them is exhausted.
+	// Otherwise nothing is counted, and it reports how long to wait.
+	Take(ctx context.Context, limits []Limit) (ok bool, retryAfter time.Duration, err error)
+	// Refund uncounts a submission taken on every limit.
+	Refund(ctx context.Context, limits []Limit) error
+}
+
+// Limits are how many submissions a user can make. Zero means unlimited.
+type Limits struct {
+	// TaskHourly and TaskDaily are counted on each task.
+	TaskHourly uint64
+	TaskDaily  uint64
+	// Hourly and Daily are counted on all tasks.
+	Hourly uint64
+	Daily  uint64
+}
+
+// forTask returns limits with per-task ones overridden by the task.
+func (l Limits) forTask(override domain.SubmissionLimit) Limits {
+	if override.Hourly != nil {
+		l.TaskHourly = *override.Hourly
+	}
+	if override.Daily != nil {
+		l.TaskDaily = *override.Daily
+	}
+
+	return l
+}
+
+func (l Limits) of(userID, taskID uuid.UUID) []Limit {
+	candidates := []Limit{
+		{Key: "user:" + userID.String() + ":task:" + taskID.String() + ":hour", Count: l.TaskHourly, Window: time.Hour},
+		{Key: "user:" + userID.String() + ":task:" + taskID.String() + ":day", Count: l.TaskDaily, Window: 24 * time.Hour},
+		{Key: "user:" + userID.String() + ":hour", Count: l.Hourly, Window: time.Hour},
+		{Key: "user:" + userID.String() + ":day", Count: l.Daily, Window: 24 * time.Hour},
+	}
+
+	limits := make([]Limit, 0, len(candidates))
+	for _, limit := range candidates {
+		if limit.Count > 0 {
+			limits = append(limits, limit)
+		}
+	}
+
+	return limits
+}
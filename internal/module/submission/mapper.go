@@ -1,6 +1,7 @@
 package submission_module
 
 import (
+	"github.com/google/uuid"
 	"github.com/oneee-playground/r2d2-api-server/internal/domain"
 	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
 )
@@ -15,7 +16,9 @@ func toSubmissionListOutput(submissions []domain.Submission) *dto.SubmissionList
 			ID:        submission.ID.String(),
 			Timestamp: submission.Timestamp,
 			IsDone:    submission.IsDone,
+			Score:     submission.Score,
 			SourceURL: url,
+			RejudgeOf: toNillableString(submission.RejudgeOf),
 			User: dto.UserInfo{
 				ID:         submission.User.ID.String(),
 				Username:   submission.User.Username,
@@ -28,6 +31,68 @@ func toSubmissionListOutput(submissions []domain.Submission) *dto.SubmissionList
 	return &out
 }
 
+func toPendingSubmissionListOutput(submissions []domain.Submission) *dto.PendingSubmissionListOutput {
+	out := make(dto.PendingSubmissionListOutput, len(submissions))
+
+	for i, submission := range submissions {
+		url := "https://github.com/" + submission.Repository
+
+		out[i] = dto.PendingSubmissionElem{
+			ID:        submission.ID.String(),
+			TaskID:    submission.TaskID.String(),
+			Timestamp: submission.Timestamp,
+			SourceURL: url,
+			User: dto.UserInfo{
+				ID:         submission.User.ID.String(),
+				Username:   submission.User.Username,
+				ProfileURL: submission.User.ProfileURL,
+				Role:       submission.User.Role.String(),
+			},
+		}
+	}
+
+	return &out
+}
+
+// toLeaderboardOutput ranks the submissions.
+// submissions should be ordered by score desc, then timestamp asc.
+// Only the first submission of each user is listed.
+func toLeaderboardOutput(submissions []domain.Submission) *dto.LeaderboardOutput {
+	out := make(dto.LeaderboardOutput, 0)
+	listed := make(map[uuid.UUID]bool)
+
+	for _, submission := range submissions {
+		if listed[submission.UserID] {
+			continue
+		}
+		listed[submission.UserID] = true
+
+		out = append(out, dto.LeaderboardElem{
+			Rank:         len(out) + 1,
+			SubmissionID: submission.ID.String(),
+			Score:        *submission.Score,
+			Timestamp:    submission.Timestamp,
+			User: dto.UserInfo{
+				ID:         submission.User.ID.String(),
+				Username:   submission.User.Username,
+				ProfileURL: submission.User.ProfileURL,
+				Role:       submission.User.Role.String(),
+			},
+		})
+	}
+
+	return &out
+}
+
+func toNillableString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+
+	s := id.String()
+	return &s
+}
+
 func toIDOutput(submission domain.Submission) *dto.IDOutput {
 	return &dto.IDOutput{
 		ID: submission.ID.String(),
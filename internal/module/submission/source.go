@@ -0,0 +1,40 @@
+package submission_module
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=source.go -destination=../../../test/mocks/source.go -package=mocks
+
+var (
+	ErrRepositoryNotFound = errors.New("repository not found")
+	ErrCommitNotFound     = errors.New("commit not found")
+)
+
+// SourceRepository is a github repository which source is submitted from.
+type SourceRepository struct {
+	// FullName is the name including the owner, e.g. "oneee-playground/empty".
+	FullName string
+	// Owner is username of the owner.
+	Owner   string
+	Private bool
+}
+
+// SourceClient looks up repositories.
+// Token is used to access private repositories. Public ones can be accessed with empty token.
+type SourceClient interface {
+	// FetchRepository returns ErrRepositoryNotFound if the repository is not visible.
+	FetchRepository(ctx context.Context, fullName, token string) (SourceRepository, error)
+	// ResolveCommit expands sha, which can be short, to full one.
+	// It returns ErrCommitNotFound if sha doesn't refer to exactly one commit.
+	ResolveCommit(ctx context.Context, fullName, sha, token string) (string, error)
+}
+
+type InstallationTokenIssuer interface {
+	// IssueInstallationToken issues short-lived token of github app installation,
+	// which can only read the repository.
+	// It returns ErrRepositoryNotFound if the installation cannot access the repository.
+	IssueInstallationToken(ctx context.Context, installationID int64, fullName string) (string, error)
+}
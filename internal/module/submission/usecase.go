@@ -2,7 +2,12 @@ package submission_module
 
 import (
 	"context"
+	stderrors "errors"
 	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,14 +17,28 @@ import (
 	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
 	"github.com/oneee-playground/r2d2-api-server/internal/global/status"
 	"github.com/oneee-playground/r2d2-api-server/internal/global/tx"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/validator"
 	"github.com/pkg/errors"
 )
 
+var (
+	repositoryPattern = regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`)
+	commitHashPattern = regexp.MustCompile(`^[0-9a-fA-F]{4,40}$`)
+)
+
 type submissionUsecase struct {
-	lock tx.Locker
+	lock    tx.Locker
+	limiter RateLimiter
+	limits  Limits
+
+	sourceClient SourceClient
+	tokenIssuer  InstallationTokenIssuer
 
 	taskRepository       domain.TaskRepository
 	submissionRepository domain.SubmissionRepository
+	revisionRepository   domain.TaskRevisionRepository
+	contestRepository    domain.ContestRepository
+	userRepository       domain.UserRepository
 	eventRepository      domain.EventRepository
 	eventPublisher       event.Publisher
 }
@@ -27,14 +46,22 @@ type submissionUsecase struct {
 var _ domain.SubmissionUsecase = (*submissionUsecase)(nil)
 
 func NewSubmissionUsecase(
-	tr domain.TaskRepository, sr domain.SubmissionRepository,
-	er domain.EventRepository, ep event.Publisher, l tx.Locker,
+	tr domain.TaskRepository, sr domain.SubmissionRepository, rr domain.TaskRevisionRepository,
+	cr domain.ContestRepository, ur domain.UserRepository, er domain.EventRepository, ep event.Publisher,
+	sc SourceClient, ti InstallationTokenIssuer, rl RateLimiter, limits Limits, l tx.Locker,
 ) *submissionUsecase {
 	return &submissionUsecase{
 		taskRepository:       tr,
 		submissionRepository: sr,
+		revisionRepository:   rr,
+		contestRepository:    cr,
+		userRepository:       ur,
 		eventRepository:      er,
 		eventPublisher:       ep,
+		sourceClient:         sc,
+		tokenIssuer:          ti,
+		limiter:              rl,
+		limits:               limits,
 		lock:                 l,
 	}
 }
@@ -53,20 +80,64 @@ func (u *submissionUsecase) GetList(ctx context.Context, in dto.SubmissionListIn
 	return toSubmissionListOutput(submissions), nil
 }
 
+func (u *submissionUsecase) GetLeaderboard(ctx context.Context, in dto.IDInput) (out *dto.LeaderboardOutput, err error) {
+	taskID := uuid.MustParse(in.ID)
+
+	if err := u.assureTaskExists(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	submissions, err := u.submissionRepository.FetchAllScored(ctx, taskID)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching scored submissions")
+	}
+
+	return toLeaderboardOutput(submissions), nil
+}
+
 func (u *submissionUsecase) Submit(ctx context.Context, in dto.SubmissionInput) (out *dto.IDOutput, err error) {
 	taskID := uuid.MustParse(in.ID)
 
+	info := auth.MustExtract(ctx)
+
+	user, err := u.userRepository.FetchByID(ctx, info.UserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching user by id")
+	}
+
+	// Verify it before locking the task, since it calls github.
+	source, commitHash, err := u.verifySource(ctx, user, in.Repository, in.CommitHash)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx, err = tx.NewAtomic(ctx, tx.AtomicOpts{
 		ReadOnly: false,
 		DataSources: []any{
 			u.taskRepository,
 			u.submissionRepository,
+			u.revisionRepository,
+			u.contestRepository,
 			u.eventRepository,
+			u.eventPublisher,
 		},
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "starting atomic transaction")
 	}
+
+	// Quota isn't part of the transaction. It is refunded if the submission isn't committed.
+	// This is deferred before evaluating the transaction, so it runs after that.
+	var taken []Limit
+	defer func() {
+		if err == nil || len(taken) == 0 {
+			return
+		}
+
+		if refundErr := u.limiter.Refund(ctx, taken); refundErr != nil {
+			err = stderrors.Join(err, errors.Wrap(refundErr, "refunding submission quota"))
+		}
+	}()
 	defer tx.Evaluate(ctx, &err)
 
 	ctx, release, err := u.lock.Acquire(ctx, "task", taskID.String())
@@ -75,8 +146,6 @@ func (u *submissionUsecase) Submit(ctx context.Context, in dto.SubmissionInput)
 	}
 	defer release()
 
-	info := auth.MustExtract(ctx)
-
 	task, err := u.taskRepository.FetchByID(ctx, taskID)
 	if err != nil {
 		if errors.Is(err, domain.ErrTaskNotFound) {
@@ -90,6 +159,18 @@ func (u *submissionUsecase) Submit(ctx context.Context, in dto.SubmissionInput)
 		return nil, status.NewErr(http.StatusForbidden, "cannot submit to non-avaiable task")
 	}
 
+	now := time.Now()
+
+	contests, err := u.contestRepository.FetchAllByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching contests of the task")
+	}
+
+	// Tasks in contests are only open while one of them is running.
+	if len(contests) > 0 && !slices.ContainsFunc(contests, func(c domain.Contest) bool { return c.IsRunning(now) }) {
+		return nil, status.NewErr(http.StatusForbidden, "task is not open outside of the contest")
+	}
+
 	exists, err := u.submissionRepository.UndoneExists(ctx, taskID, info.UserID)
 	if err != nil {
 		return nil, errors.Wrap(err, "checking if unfinished submission exists")
@@ -99,13 +180,28 @@ func (u *submissionUsecase) Submit(ctx context.Context, in dto.SubmissionInput)
 		return nil, status.NewErr(http.StatusConflict, "unfinished submission exists")
 	}
 
+	taken, err = u.takeQuota(ctx, user, task)
+	if err != nil {
+		return nil, err
+	}
+
 	submission := domain.Submission{
 		ID:         uuid.New(),
-		Timestamp:  time.Now(),
+		Timestamp:  now,
 		UserID:     info.UserID,
 		TaskID:     taskID,
-		Repository: in.Repository,
-		CommitHash: in.CommitHash,
+		Repository: source.FullName,
+		CommitHash: commitHash,
+	}
+
+	revision, err := u.revisionRepository.FetchLatest(ctx, taskID)
+	if err != nil && !errors.Is(err, domain.ErrRevisionNotFound) {
+		return nil, errors.Wrap(err, "fetching latest revision")
+	}
+
+	// Tasks made available before revisions were introduced don't have one.
+	if err == nil {
+		submission.RevisionID = &revision.ID
 	}
 
 	if err := u.submissionRepository.Create(ctx, submission); err != nil {
@@ -125,6 +221,23 @@ func (u *submissionUsecase) Submit(ctx context.Context, in dto.SubmissionInput)
 		return nil, errors.Wrap(err, "creating event")
 	}
 
+	rule, approved, err := u.matchApprovalRule(ctx, task.ApprovalPolicy, submission, source)
+	if err != nil {
+		return nil, err
+	}
+
+	if approved {
+		submission.IsDone = true
+		if err := u.submissionRepository.Update(ctx, submission); err != nil {
+			return nil, errors.Wrap(err, "updating submission")
+		}
+
+		extra := "approved automatically by rule " + string(rule)
+		if err := u.publishSubmissionEvent(ctx, domain.KindApprove, extra, submission); err != nil {
+			return nil, err
+		}
+	}
+
 	return toIDOutput(submission), nil
 }
 
@@ -168,13 +281,227 @@ func (u *submissionUsecase) DecideApproval(ctx context.Context, in dto.Submissio
 		return status.NewErr(http.StatusForbidden, "submission is already done")
 	}
 
-	submission.IsDone = true
-	if err := u.submissionRepository.Update(ctx, submission); err != nil {
-		return errors.Wrap(err, "updatnig submission")
+	return u.decide(ctx, submission, domain.SubmissionAction(in.Action), in.Extra)
+}
+
+func (u *submissionUsecase) GetPendingList(ctx context.Context, in dto.PendingSubmissionListInput) (out *dto.PendingSubmissionListOutput, err error) {
+	var filter domain.PendingFilter
+
+	if in.TaskID != "" {
+		taskID := uuid.MustParse(in.TaskID)
+		filter.TaskID = &taskID
+	}
+
+	if in.UserID != "" {
+		userID := uuid.MustParse(in.UserID)
+		filter.UserID = &userID
+	}
+
+	if in.MinAge > 0 {
+		submittedBefore := time.Now().Add(-time.Duration(in.MinAge) * time.Second)
+		filter.SubmittedBefore = &submittedBefore
 	}
 
+	submissions, err := u.submissionRepository.FetchAllPending(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching pending submissions")
+	}
+
+	return toPendingSubmissionListOutput(submissions), nil
+}
+
+func (u *submissionUsecase) DecideBulk(ctx context.Context, in dto.BulkDecisionInput) (out *dto.BulkDecisionOutput, err error) {
 	action := domain.SubmissionAction(in.Action)
+	if !validator.SubmissionAcitonValid(action) {
+		return nil, status.NewErr(http.StatusBadRequest, "invalid action")
+	}
+
+	ctx, err = tx.NewAtomic(ctx, tx.AtomicOpts{
+		ReadOnly: false,
+		DataSources: []any{
+			u.submissionRepository,
+			u.eventPublisher,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "starting atomic transaction")
+	}
+	defer tx.Evaluate(ctx, &err)
 
+	out = &dto.BulkDecisionOutput{
+		Decided: make([]string, 0),
+		Skipped: make([]string, 0),
+	}
+
+	submissions := make([]domain.Submission, 0, len(in.SubmissionIDs))
+	for _, rawID := range in.SubmissionIDs {
+		submission, err := u.submissionRepository.FetchByID(ctx, uuid.MustParse(rawID))
+		if err != nil {
+			if errors.Is(err, domain.ErrSubmissionNotFound) {
+				out.Skipped = append(out.Skipped, rawID)
+				continue
+			}
+			return nil, errors.Wrap(err, "fetching submission")
+		}
+
+		submissions = append(submissions, submission)
+	}
+
+	// Tasks are locked in order, so that bulk decisions don't deadlock each other.
+	taskIDs := make([]string, 0)
+	for _, submission := range submissions {
+		taskIDs = append(taskIDs, submission.TaskID.String())
+	}
+	slices.Sort(taskIDs)
+
+	for _, taskID := range slices.Compact(taskIDs) {
+		var release func()
+		ctx, release, err = u.lock.Acquire(ctx, "task", taskID)
+		if err != nil {
+			return nil, errors.Wrap(err, "acquiring lock")
+		}
+		defer release()
+	}
+
+	for _, submission := range submissions {
+		// Fetch again, since it might be decided before locking.
+		submission, err := u.submissionRepository.FetchByID(ctx, submission.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetching submission")
+		}
+
+		if submission.IsDone {
+			out.Skipped = append(out.Skipped, submission.ID.String())
+			continue
+		}
+
+		if err := u.decide(ctx, submission, action, in.Extra); err != nil {
+			return nil, err
+		}
+
+		out.Decided = append(out.Decided, submission.ID.String())
+	}
+
+	return out, nil
+}
+
+// Rejudge creates re-runs of the task's past submissions.
+// Re-runs are queued undone, and dispatched little by little so that job queue is not flooded.
+func (u *submissionUsecase) Rejudge(ctx context.Context, in dto.RejudgeInput) (out *dto.RejudgeOutput, err error) {
+	taskID := uuid.MustParse(in.ID)
+
+	if in.From != nil && in.To != nil && in.From.After(*in.To) {
+		return nil, status.NewErr(http.StatusBadRequest, "from should be before to")
+	}
+
+	ctx, err = tx.NewAtomic(ctx, tx.AtomicOpts{
+		ReadOnly: false,
+		DataSources: []any{
+			u.taskRepository,
+			u.submissionRepository,
+			u.revisionRepository,
+			u.eventRepository,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "starting atomic transaction")
+	}
+	defer tx.Evaluate(ctx, &err)
+
+	ctx, release, err := u.lock.Acquire(ctx, "task", taskID.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "acquiring lock")
+	}
+	defer release()
+
+	task, err := u.taskRepository.FetchByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return nil, status.NewErr(http.StatusNotFound, err.Error())
+		}
+
+		return nil, err
+	}
+
+	if task.Stage != domain.StageAvailable {
+		return nil, status.NewErr(http.StatusForbidden, "cannot rejudge non-available task")
+	}
+
+	originals, err := u.submissionRepository.FetchAllApproved(ctx, taskID, in.From, in.To)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching approved submissions")
+	}
+
+	if in.LatestPerUser {
+		originals = latestPerUser(originals)
+	}
+
+	revision, err := u.revisionRepository.FetchLatest(ctx, taskID)
+	if err != nil && !errors.Is(err, domain.ErrRevisionNotFound) {
+		return nil, errors.Wrap(err, "fetching latest revision")
+	}
+
+	var revisionID *uuid.UUID
+	if err == nil {
+		revisionID = &revision.ID
+	}
+
+	now := time.Now()
+
+	for _, original := range originals {
+		rerun := domain.Submission{
+			ID:         uuid.New(),
+			Timestamp:  now,
+			UserID:     original.UserID,
+			TaskID:     taskID,
+			Repository: original.Repository,
+			CommitHash: original.CommitHash,
+			RevisionID: revisionID,
+			RejudgeOf:  &original.ID,
+		}
+
+		if err := u.submissionRepository.Create(ctx, rerun); err != nil {
+			return nil, errors.Wrap(err, "creating re-run")
+		}
+
+		event := domain.Event{
+			ID:           uuid.New(),
+			Kind:         domain.KindSubmit,
+			Extra:        "rejudge of " + original.ID.String(),
+			Timestamp:    now,
+			SubmissionID: rerun.ID,
+		}
+
+		if err := u.eventRepository.Create(ctx, event); err != nil {
+			return nil, errors.Wrap(err, "creating event")
+		}
+	}
+
+	return &dto.RejudgeOutput{Count: len(originals)}, nil
+}
+
+// latestPerUser leaves the first submission of each user.
+// submissions should be ordered by timestamp desc.
+func latestPerUser(submissions []domain.Submission) []domain.Submission {
+	latest := make([]domain.Submission, 0)
+	listed := make(map[uuid.UUID]bool)
+
+	for _, submission := range submissions {
+		if listed[submission.UserID] {
+			continue
+		}
+		listed[submission.UserID] = true
+
+		latest = append(latest, submission)
+	}
+
+	return latest
+}
+
+// decide marks the submission done and publishes the decision.
+func (u *submissionUsecase) decide(
+	ctx context.Context, submission domain.Submission, action domain.SubmissionAction, extra string,
+) error {
 	var eventKind domain.EventKind
 	switch action {
 	case domain.ActionApprove:
@@ -185,7 +512,12 @@ func (u *submissionUsecase) DecideApproval(ctx context.Context, in dto.Submissio
 		return errors.New("invalid action given")
 	}
 
-	if err := u.publishSubmissionEvent(ctx, eventKind, in.Extra, submission); err != nil {
+	submission.IsDone = true
+	if err := u.submissionRepository.Update(ctx, submission); err != nil {
+		return errors.Wrap(err, "updatnig submission")
+	}
+
+	if err := u.publishSubmissionEvent(ctx, eventKind, extra, submission); err != nil {
 		return err
 	}
 
@@ -201,6 +533,7 @@ func (u *submissionUsecase) Cancel(ctx context.Context, in dto.SubmissionIDInput
 		DataSources: []any{
 			u.taskRepository,
 			u.submissionRepository,
+			u.eventRepository,
 			u.eventPublisher,
 		},
 	})
@@ -230,8 +563,20 @@ func (u *submissionUsecase) Cancel(ctx context.Context, in dto.SubmissionIDInput
 		return errors.Wrap(err, "fetching submission")
 	}
 
+	if submission.IsCancelled {
+		return status.NewErr(http.StatusForbidden, "submission is already cancelled")
+	}
+
+	// Approved submissions can be cancelled while they are executed.
 	if submission.IsDone {
-		return status.NewErr(http.StatusForbidden, "submission is already done")
+		running, err := u.isRunning(ctx, submission.ID)
+		if err != nil {
+			return err
+		}
+
+		if !running {
+			return status.NewErr(http.StatusForbidden, "submission is already done")
+		}
 	}
 
 	hasPermission := submission.UserID == info.UserID || info.Role == domain.RoleAdmin
@@ -240,6 +585,7 @@ func (u *submissionUsecase) Cancel(ctx context.Context, in dto.SubmissionIDInput
 	}
 
 	submission.IsDone = true
+	submission.IsCancelled = true
 	if err := u.submissionRepository.Update(ctx, submission); err != nil {
 		return errors.Wrap(err, "updatnig submission")
 	}
@@ -251,6 +597,27 @@ func (u *submissionUsecase) Cancel(ctx context.Context, in dto.SubmissionIDInput
 	return nil
 }
 
+// isRunning reports whether the submission is approved, and its execution is not over yet.
+func (u *submissionUsecase) isRunning(ctx context.Context, submissionID uuid.UUID) (bool, error) {
+	events, err := u.eventRepository.FetchAllBySubmissionID(ctx, submissionID)
+	if err != nil {
+		return false, errors.Wrap(err, "fetching events of submission")
+	}
+
+	approved := false
+	for _, event := range events {
+		if slices.Contains(domain.FinishingKinds, event.Kind) {
+			return false, nil
+		}
+
+		if event.Kind == domain.KindApprove {
+			approved = true
+		}
+	}
+
+	return approved, nil
+}
+
 // assureTaskExists checks if task exists. if not exists, it will return an error.
 func (u *submissionUsecase) assureTaskExists(ctx context.Context, taskID uuid.UUID) error {
 	exists, err := u.taskRepository.ExistsByID(ctx, taskID)
@@ -283,3 +650,78 @@ func (u *submissionUsecase) publishSubmissionEvent(
 
 	return nil
 }
+
+// takeQuota counts the submission against limits of the user, and returns limits taken.
+// It returns error if the user has exhausted any of them.
+func (u *submissionUsecase) takeQuota(ctx context.Context, user domain.User, task domain.Task) ([]Limit, error) {
+	limits := u.limits.forTask(task.SubmissionLimit).of(user.ID, task.ID)
+	if len(limits) == 0 || user.LimitExempt {
+		return nil, nil
+	}
+
+	ok, retryAfter, err := u.limiter.Take(ctx, limits)
+	if err != nil {
+		return nil, errors.Wrap(err, "taking submission quota")
+	}
+
+	if !ok {
+		// Round up so clients don't retry too early.
+		seconds := int((retryAfter + time.Second - 1) / time.Second)
+
+		details := dto.RateLimitDetails{RetryAfter: seconds}
+		return nil, status.NewErrWithDetails(http.StatusTooManyRequests, "submission limit exceeded", details).
+			WithHeader("Retry-After", strconv.Itoa(seconds))
+	}
+
+	return limits, nil
+}
+
+// verifySource checks the source is visible to the user, and resolves full commit hash of it.
+func (u *submissionUsecase) verifySource(
+	ctx context.Context, user domain.User, fullName, sha string,
+) (SourceRepository, string, error) {
+	if !repositoryPattern.MatchString(fullName) {
+		return SourceRepository{}, "", status.NewErr(http.StatusBadRequest, "repository should be in form of owner/name")
+	}
+
+	if !commitHashPattern.MatchString(sha) {
+		return SourceRepository{}, "", status.NewErr(http.StatusBadRequest, "commit hash should be 4 to 40 hexadecimal characters")
+	}
+
+	var token string
+
+	if user.InstallationID != nil {
+		var err error
+
+		token, err = u.tokenIssuer.IssueInstallationToken(ctx, *user.InstallationID, fullName)
+		// Repositories without the app can still be public.
+		if err != nil && !errors.Is(err, ErrRepositoryNotFound) {
+			return SourceRepository{}, "", errors.Wrap(err, "issuing installation token")
+		}
+	}
+
+	source, err := u.sourceClient.FetchRepository(ctx, fullName, token)
+	if err != nil {
+		if errors.Is(err, ErrRepositoryNotFound) {
+			return SourceRepository{}, "", status.NewErr(http.StatusBadRequest, "repository does not exist or is not accessible")
+		}
+
+		return SourceRepository{}, "", errors.Wrap(err, "fetching repository")
+	}
+
+	// Github usernames are case-insensitive.
+	if source.Private && !strings.EqualFold(source.Owner, user.Username) {
+		return SourceRepository{}, "", status.NewErr(http.StatusBadRequest, "private repository should be owned by the submitter")
+	}
+
+	commitHash, err := u.sourceClient.ResolveCommit(ctx, source.FullName, sha, token)
+	if err != nil {
+		if errors.Is(err, ErrCommitNotFound) {
+			return SourceRepository{}, "", status.NewErr(http.StatusBadRequest, "commit does not exist in the repository or is ambiguous")
+		}
+
+		return SourceRepository{}, "", errors.Wrap(err, "resolving commit")
+	}
+
+	return source, commitHash, nil
+}
@@ -4,16 +4,19 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/oneee-playground/r2d2-api-server/internal/domain"
 	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
 	"github.com/oneee-playground/r2d2-api-server/internal/global/auth"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
 	"github.com/oneee-playground/r2d2-api-server/internal/global/status"
 	submission_module "github.com/oneee-playground/r2d2-api-server/internal/module/submission"
 	"github.com/oneee-playground/r2d2-api-server/test/mocks"
 	"github.com/oneee-playground/r2d2-api-server/test/stubs"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -30,8 +33,14 @@ type SubmissionUsecaseSuite struct {
 	mock struct {
 		taskRepository       *mocks.MockTaskRepository
 		submissionRepository *mocks.MockSubmissionRepository
+		revisionRepository   *mocks.MockTaskRevisionRepository
+		contestRepository    *mocks.MockContestRepository
+		userRepository       *mocks.MockUserRepository
 		eventRepository      *mocks.MockEventRepository
 		eventPublisher       *mocks.MockPublisher
+		rateLimiter          *mocks.MockRateLimiter
+		sourceClient         *mocks.MockSourceClient
+		tokenIssuer          *mocks.MockInstallationTokenIssuer
 	}
 	stub struct {
 		locker *stubs.StubLocker
@@ -42,19 +51,42 @@ func (s *SubmissionUsecaseSuite) SetupTest() {
 	s.ctl = gomock.NewController(s.T())
 	s.mock.taskRepository = mocks.NewMockTaskRepository(s.ctl)
 	s.mock.submissionRepository = mocks.NewMockSubmissionRepository(s.ctl)
+	s.mock.revisionRepository = mocks.NewMockTaskRevisionRepository(s.ctl)
+	s.mock.contestRepository = mocks.NewMockContestRepository(s.ctl)
+	s.mock.userRepository = mocks.NewMockUserRepository(s.ctl)
 	s.mock.eventRepository = mocks.NewMockEventRepository(s.ctl)
 	s.mock.eventPublisher = mocks.NewMockPublisher(s.ctl)
+	s.mock.rateLimiter = mocks.NewMockRateLimiter(s.ctl)
+	s.mock.sourceClient = mocks.NewMockSourceClient(s.ctl)
+	s.mock.tokenIssuer = mocks.NewMockInstallationTokenIssuer(s.ctl)
 	s.stub.locker = stubs.NewStubLocker()
 
 	s.usecase = submission_module.NewSubmissionUsecase(
-		s.mock.taskRepository, s.mock.submissionRepository,
-		s.mock.eventRepository, s.mock.eventPublisher, s.stub.locker,
+		s.mock.taskRepository, s.mock.submissionRepository, s.mock.revisionRepository,
+		s.mock.contestRepository, s.mock.userRepository, s.mock.eventRepository, s.mock.eventPublisher,
+		s.mock.sourceClient, s.mock.tokenIssuer, s.mock.rateLimiter, submission_module.Limits{Hourly: 1}, s.stub.locker,
 	)
 }
 
 func (s *SubmissionUsecaseSuite) TestSubmit() {
 	availableTask := domain.Task{Stage: domain.StageAvailable}
 	draftTask := domain.Task{Stage: domain.StageDraft}
+	revision := domain.TaskRevision{ID: uuid.New()}
+
+	runningContest := domain.Contest{StartAt: time.Now().Add(-time.Hour), EndAt: time.Now().Add(time.Hour)}
+	endedContest := domain.Contest{StartAt: time.Now().Add(-2 * time.Hour), EndAt: time.Now().Add(-time.Hour)}
+
+	source := submission_module.SourceRepository{FullName: "owner/repo", Owner: "owner"}
+	fullHash := "0123456789abcdef0123456789abcdef01234567"
+
+	expectSource := func(user domain.User) {
+		s.mock.userRepository.EXPECT().
+			FetchByID(gomock.Any(), gomock.Any()).Return(user, nil)
+		s.mock.sourceClient.EXPECT().
+			FetchRepository(gomock.Any(), source.FullName, "").Return(source, nil)
+		s.mock.sourceClient.EXPECT().
+			ResolveCommit(gomock.Any(), source.FullName, "0123456", "").Return(fullHash, nil)
+	}
 
 	testcases := []struct {
 		desc     string
@@ -64,10 +96,53 @@ func (s *SubmissionUsecaseSuite) TestSubmit() {
 		{
 			desc: "success",
 			setup: func() {
+				expectSource(domain.User{})
 				s.mock.taskRepository.EXPECT().
 					FetchByID(gomock.Any(), gomock.Any()).Return(availableTask, nil)
+				s.mock.contestRepository.EXPECT().
+					FetchAllByTaskID(gomock.Any(), gomock.Any()).Return([]domain.Contest{runningContest}, nil)
 				s.mock.submissionRepository.EXPECT().
 					UndoneExists(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				s.mock.revisionRepository.EXPECT().
+					FetchLatest(gomock.Any(), gomock.Any()).Return(revision, nil)
+				s.mock.submissionRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, submission domain.Submission) {
+						s.Equal(&revision.ID, submission.RevisionID)
+						s.Equal(fullHash, submission.CommitHash)
+					}).
+					Return(nil)
+				s.mock.eventRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).Return(nil)
+				s.mock.rateLimiter.EXPECT().
+					Take(gomock.Any(), gomock.Any()).Return(true, time.Duration(0), nil)
+			},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc: "task overrides limits",
+			setup: func() {
+				taskHourly := uint64(3)
+				overridden := availableTask
+				overridden.SubmissionLimit = domain.SubmissionLimit{Hourly: &taskHourly}
+
+				expectSource(domain.User{})
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), gomock.Any()).Return(overridden, nil)
+				s.mock.contestRepository.EXPECT().
+					FetchAllByTaskID(gomock.Any(), gomock.Any()).Return(nil, nil)
+				s.mock.submissionRepository.EXPECT().
+					UndoneExists(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				s.mock.rateLimiter.EXPECT().
+					Take(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, limits []submission_module.Limit) {
+						s.Require().Len(limits, 2)
+						s.Equal(taskHourly, limits[0].Count)
+						s.Equal(uint64(1), limits[1].Count)
+					}).
+					Return(true, time.Duration(0), nil)
+				s.mock.revisionRepository.EXPECT().
+					FetchLatest(gomock.Any(), gomock.Any()).Return(revision, nil)
 				s.mock.submissionRepository.EXPECT().
 					Create(gomock.Any(), gomock.Any()).Return(nil)
 				s.mock.eventRepository.EXPECT().
@@ -78,6 +153,7 @@ func (s *SubmissionUsecaseSuite) TestSubmit() {
 		{
 			desc: "task is not available",
 			setup: func() {
+				expectSource(domain.User{})
 				s.mock.taskRepository.EXPECT().
 					FetchByID(gomock.Any(), gomock.Any()).Return(draftTask, nil)
 			},
@@ -86,9 +162,83 @@ func (s *SubmissionUsecaseSuite) TestSubmit() {
 				return ok && sErr.StatusCode == http.StatusForbidden
 			},
 		},
+		{
+			desc: "exempted from limits",
+			setup: func() {
+				expectSource(domain.User{LimitExempt: true})
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), gomock.Any()).Return(availableTask, nil)
+				s.mock.contestRepository.EXPECT().
+					FetchAllByTaskID(gomock.Any(), gomock.Any()).Return(nil, nil)
+				s.mock.submissionRepository.EXPECT().
+					UndoneExists(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				s.mock.revisionRepository.EXPECT().
+					FetchLatest(gomock.Any(), gomock.Any()).Return(domain.TaskRevision{}, domain.ErrRevisionNotFound)
+				s.mock.submissionRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).Return(nil)
+				s.mock.eventRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc: "limit exceeded",
+			setup: func() {
+				expectSource(domain.User{})
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), gomock.Any()).Return(availableTask, nil)
+				s.mock.contestRepository.EXPECT().
+					FetchAllByTaskID(gomock.Any(), gomock.Any()).Return(nil, nil)
+				s.mock.submissionRepository.EXPECT().
+					UndoneExists(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				s.mock.rateLimiter.EXPECT().
+					Take(gomock.Any(), gomock.Any()).Return(false, 1500*time.Millisecond, nil)
+			},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusTooManyRequests &&
+					sErr.Headers["Retry-After"] == "2"
+			},
+		},
+		{
+			desc: "quota refunded on failure",
+			setup: func() {
+				expectSource(domain.User{})
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), gomock.Any()).Return(availableTask, nil)
+				s.mock.contestRepository.EXPECT().
+					FetchAllByTaskID(gomock.Any(), gomock.Any()).Return(nil, nil)
+				s.mock.submissionRepository.EXPECT().
+					UndoneExists(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				s.mock.rateLimiter.EXPECT().
+					Take(gomock.Any(), gomock.Any()).Return(true, time.Duration(0), nil)
+				s.mock.revisionRepository.EXPECT().
+					FetchLatest(gomock.Any(), gomock.Any()).Return(revision, nil)
+				s.mock.submissionRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).Return(errors.New("db down"))
+				s.mock.rateLimiter.EXPECT().
+					Refund(gomock.Any(), gomock.Not(gomock.Len(0))).Return(nil)
+			},
+			checkErr: func(err error) bool { return err != nil },
+		},
+		{
+			desc: "outside of the contest",
+			setup: func() {
+				expectSource(domain.User{})
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), gomock.Any()).Return(availableTask, nil)
+				s.mock.contestRepository.EXPECT().
+					FetchAllByTaskID(gomock.Any(), gomock.Any()).Return([]domain.Contest{endedContest}, nil)
+			},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusForbidden
+			},
+		},
 		{
 			desc: "task does not exist",
 			setup: func() {
+				expectSource(domain.User{})
 				s.mock.taskRepository.EXPECT().
 					FetchByID(gomock.Any(), gomock.Any()).Return(availableTask, domain.ErrTaskNotFound)
 			},
@@ -100,8 +250,11 @@ func (s *SubmissionUsecaseSuite) TestSubmit() {
 		{
 			desc: "duplicate submission",
 			setup: func() {
+				expectSource(domain.User{})
 				s.mock.taskRepository.EXPECT().
 					FetchByID(gomock.Any(), gomock.Any()).Return(availableTask, nil)
+				s.mock.contestRepository.EXPECT().
+					FetchAllByTaskID(gomock.Any(), gomock.Any()).Return(nil, nil)
 				s.mock.submissionRepository.EXPECT().
 					UndoneExists(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
 			},
@@ -117,12 +270,230 @@ func (s *SubmissionUsecaseSuite) TestSubmit() {
 		s.Run(tc.desc, func() {
 			tc.setup()
 
-			_, err := s.usecase.Submit(ctx, dto.SubmissionInput{IDInput: dto.IDInput{ID: uuid.Nil.String()}})
+			_, err := s.usecase.Submit(ctx, dto.SubmissionInput{
+				IDInput:    dto.IDInput{ID: uuid.Nil.String()},
+				Repository: source.FullName,
+				CommitHash: "0123456",
+			})
 			s.True(tc.checkErr(err), err)
 		})
 	}
 }
 
+func (s *SubmissionUsecaseSuite) TestSubmitVerifiesSource() {
+	user := domain.User{ID: uuid.New(), Username: "user"}
+
+	testcases := []struct {
+		desc  string
+		in    dto.SubmissionInput
+		setup func()
+	}{
+		{
+			desc:  "malformed repository",
+			in:    dto.SubmissionInput{Repository: "https://github.com/user/repo", CommitHash: "0123456"},
+			setup: func() {},
+		},
+		{
+			desc:  "malformed commit hash",
+			in:    dto.SubmissionInput{Repository: "user/repo", CommitHash: "main"},
+			setup: func() {},
+		},
+		{
+			desc: "repository not found",
+			in:   dto.SubmissionInput{Repository: "user/repo", CommitHash: "0123456"},
+			setup: func() {
+				s.mock.sourceClient.EXPECT().
+					FetchRepository(gomock.Any(), "user/repo", "").
+					Return(submission_module.SourceRepository{}, submission_module.ErrRepositoryNotFound)
+			},
+		},
+		{
+			desc: "private repository of others",
+			in:   dto.SubmissionInput{Repository: "other/repo", CommitHash: "0123456"},
+			setup: func() {
+				s.mock.sourceClient.EXPECT().
+					FetchRepository(gomock.Any(), "other/repo", "").
+					Return(submission_module.SourceRepository{FullName: "other/repo", Owner: "other", Private: true}, nil)
+			},
+		},
+		{
+			desc: "commit not found",
+			in:   dto.SubmissionInput{Repository: "user/repo", CommitHash: "0123456"},
+			setup: func() {
+				s.mock.sourceClient.EXPECT().
+					FetchRepository(gomock.Any(), "user/repo", "").
+					Return(submission_module.SourceRepository{FullName: "user/repo", Owner: "user"}, nil)
+				s.mock.sourceClient.EXPECT().
+					ResolveCommit(gomock.Any(), "user/repo", "0123456", "").
+					Return("", submission_module.ErrCommitNotFound)
+			},
+		},
+	}
+
+	ctx := auth.Inject(context.Background(), auth.Payload{UserID: user.ID})
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			s.mock.userRepository.EXPECT().
+				FetchByID(gomock.Any(), user.ID).Return(user, nil)
+
+			tc.setup()
+
+			tc.in.IDInput = dto.IDInput{ID: uuid.Nil.String()}
+
+			_, err := s.usecase.Submit(ctx, tc.in)
+			sErr, ok := err.(status.Error)
+			s.True(ok && sErr.StatusCode == http.StatusBadRequest, err)
+		})
+	}
+}
+
+func (s *SubmissionUsecaseSuite) TestSubmitWithInstallation() {
+	installationID := int64(1)
+	user := domain.User{ID: uuid.New(), Username: "user", InstallationID: &installationID}
+	source := submission_module.SourceRepository{FullName: "user/private", Owner: "user", Private: true}
+
+	s.mock.userRepository.EXPECT().
+		FetchByID(gomock.Any(), user.ID).Return(user, nil)
+	s.mock.tokenIssuer.EXPECT().
+		IssueInstallationToken(gomock.Any(), installationID, source.FullName).Return("token", nil)
+	s.mock.sourceClient.EXPECT().
+		FetchRepository(gomock.Any(), source.FullName, "token").Return(source, nil)
+	s.mock.sourceClient.EXPECT().
+		ResolveCommit(gomock.Any(), source.FullName, "0123456", "token").Return("0123456789", nil)
+	s.mock.taskRepository.EXPECT().
+		FetchByID(gomock.Any(), gomock.Any()).Return(domain.Task{Stage: domain.StageAvailable}, nil)
+	s.mock.contestRepository.EXPECT().
+		FetchAllByTaskID(gomock.Any(), gomock.Any()).Return(nil, nil)
+	s.mock.submissionRepository.EXPECT().
+		UndoneExists(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	s.mock.rateLimiter.EXPECT().
+		Take(gomock.Any(), gomock.Any()).Return(true, time.Duration(0), nil)
+	s.mock.revisionRepository.EXPECT().
+		FetchLatest(gomock.Any(), gomock.Any()).Return(domain.TaskRevision{}, domain.ErrRevisionNotFound)
+	s.mock.submissionRepository.EXPECT().
+		Create(gomock.Any(), gomock.Any()).Return(nil)
+	s.mock.eventRepository.EXPECT().
+		Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	ctx := auth.Inject(context.Background(), auth.Payload{UserID: user.ID})
+
+	_, err := s.usecase.Submit(ctx, dto.SubmissionInput{
+		IDInput:    dto.IDInput{ID: uuid.Nil.String()},
+		Repository: source.FullName,
+		CommitHash: "0123456",
+	})
+	s.NoError(err)
+}
+
+func (s *SubmissionUsecaseSuite) TestSubmitAutoApproval() {
+	user := domain.User{ID: uuid.New(), Username: "user"}
+	source := submission_module.SourceRepository{FullName: "org/repo", Owner: "Org"}
+
+	testcases := []struct {
+		desc     string
+		policy   domain.ApprovalPolicy
+		setup    func()
+		approved bool
+	}{
+		{
+			desc:     "manual",
+			policy:   domain.ApprovalPolicy{Rule: domain.RuleManual},
+			setup:    func() {},
+			approved: false,
+		},
+		{
+			desc:     "all",
+			policy:   domain.ApprovalPolicy{Rule: domain.RuleAll},
+			setup:    func() {},
+			approved: true,
+		},
+		{
+			desc:   "approved before",
+			policy: domain.ApprovalPolicy{Rule: domain.RuleApprovedBefore},
+			setup: func() {
+				s.mock.submissionRepository.EXPECT().
+					ApprovedExists(gomock.Any(), user.ID).Return(true, nil)
+			},
+			approved: true,
+		},
+		{
+			desc:   "never approved",
+			policy: domain.ApprovalPolicy{Rule: domain.RuleApprovedBefore},
+			setup: func() {
+				s.mock.submissionRepository.EXPECT().
+					ApprovedExists(gomock.Any(), user.ID).Return(false, nil)
+			},
+			approved: false,
+		},
+		{
+			desc:     "allowed org",
+			policy:   domain.ApprovalPolicy{Rule: domain.RuleAllowedOrgs, AllowedOrgs: []string{"org"}},
+			setup:    func() {},
+			approved: true,
+		},
+		{
+			desc:     "not allowed org",
+			policy:   domain.ApprovalPolicy{Rule: domain.RuleAllowedOrgs, AllowedOrgs: []string{"other"}},
+			setup:    func() {},
+			approved: false,
+		},
+	}
+
+	ctx := auth.Inject(context.Background(), auth.Payload{UserID: user.ID})
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			s.mock.userRepository.EXPECT().
+				FetchByID(gomock.Any(), user.ID).Return(user, nil)
+			s.mock.sourceClient.EXPECT().
+				FetchRepository(gomock.Any(), source.FullName, "").Return(source, nil)
+			s.mock.sourceClient.EXPECT().
+				ResolveCommit(gomock.Any(), source.FullName, "0123456", "").Return("0123456789", nil)
+			s.mock.taskRepository.EXPECT().
+				FetchByID(gomock.Any(), gomock.Any()).
+				Return(domain.Task{Stage: domain.StageAvailable, ApprovalPolicy: tc.policy}, nil)
+			s.mock.contestRepository.EXPECT().
+				FetchAllByTaskID(gomock.Any(), gomock.Any()).Return(nil, nil)
+			s.mock.submissionRepository.EXPECT().
+				UndoneExists(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+			s.mock.rateLimiter.EXPECT().
+				Take(gomock.Any(), gomock.Any()).Return(true, time.Duration(0), nil)
+			s.mock.revisionRepository.EXPECT().
+				FetchLatest(gomock.Any(), gomock.Any()).Return(domain.TaskRevision{}, domain.ErrRevisionNotFound)
+			s.mock.submissionRepository.EXPECT().
+				Create(gomock.Any(), gomock.Any()).Return(nil)
+			s.mock.eventRepository.EXPECT().
+				Create(gomock.Any(), gomock.Any()).Return(nil)
+
+			tc.setup()
+
+			if tc.approved {
+				s.mock.submissionRepository.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, submission domain.Submission) {
+						s.True(submission.IsDone)
+					}).
+					Return(nil)
+				s.mock.eventPublisher.EXPECT().
+					Publish(gomock.Any(), event.TopicSubmission, gomock.Any()).
+					Do(func(_ context.Context, _ event.Topic, e any) {
+						ev := e.(event.SubmissionEvent)
+						s.Equal(domain.KindApprove, ev.Kind)
+						s.Contains(ev.Extra, string(tc.policy.Rule))
+					}).
+					Return(nil)
+			}
+
+			_, err := s.usecase.Submit(ctx, dto.SubmissionInput{
+				IDInput:    dto.IDInput{ID: uuid.Nil.String()},
+				Repository: source.FullName,
+				CommitHash: "0123456",
+			})
+			s.NoError(err)
+		})
+	}
+}
+
 func (s *SubmissionUsecaseSuite) TestDecideApproval() {
 	undoneSubmission := domain.Submission{IsDone: false}
 	doneSubmission := domain.Submission{IsDone: true}
@@ -221,6 +592,159 @@ func (s *SubmissionUsecaseSuite) TestDecideApproval() {
 	}
 }
 
+func (s *SubmissionUsecaseSuite) TestGetPendingList() {
+	taskID := uuid.New()
+	user := domain.User{ID: uuid.New(), Role: domain.RoleMember}
+
+	s.mock.submissionRepository.EXPECT().
+		FetchAllPending(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter domain.PendingFilter) ([]domain.Submission, error) {
+			s.Equal(&taskID, filter.TaskID)
+			s.Nil(filter.UserID)
+			s.WithinDuration(time.Now().Add(-time.Hour), *filter.SubmittedBefore, time.Minute)
+
+			return []domain.Submission{{ID: uuid.New(), TaskID: taskID, UserID: user.ID, User: &user}}, nil
+		})
+
+	out, err := s.usecase.GetPendingList(context.Background(), dto.PendingSubmissionListInput{
+		TaskID: taskID.String(),
+		MinAge: 3600,
+	})
+	s.NoError(err)
+	s.Len(*out, 1)
+}
+
+func (s *SubmissionUsecaseSuite) TestDecideBulk() {
+	pending := domain.Submission{ID: uuid.New(), TaskID: uuid.New()}
+	done := domain.Submission{ID: uuid.New(), TaskID: uuid.New(), IsDone: true}
+	missing := uuid.New()
+
+	s.mock.submissionRepository.EXPECT().
+		FetchByID(gomock.Any(), pending.ID).Return(pending, nil).Times(2)
+	s.mock.submissionRepository.EXPECT().
+		FetchByID(gomock.Any(), done.ID).Return(done, nil).Times(2)
+	s.mock.submissionRepository.EXPECT().
+		FetchByID(gomock.Any(), missing).Return(domain.Submission{}, domain.ErrSubmissionNotFound)
+	s.mock.submissionRepository.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, submission domain.Submission) {
+			s.Equal(pending.ID, submission.ID)
+			s.True(submission.IsDone)
+		}).
+		Return(nil)
+	s.mock.eventPublisher.EXPECT().
+		Publish(gomock.Any(), event.TopicSubmission, gomock.Any()).
+		Do(func(_ context.Context, _ event.Topic, e any) {
+			ev := e.(event.SubmissionEvent)
+			s.Equal(pending.ID, ev.SubmissionID)
+			s.Equal(domain.KindReject, ev.Kind)
+		}).
+		Return(nil)
+
+	out, err := s.usecase.DecideBulk(context.Background(), dto.BulkDecisionInput{
+		SubmissionIDs: []string{pending.ID.String(), done.ID.String(), missing.String()},
+		Action:        string(domain.ActionReject),
+		Extra:         "reason",
+	})
+	s.NoError(err)
+	s.Equal([]string{pending.ID.String()}, out.Decided)
+	s.ElementsMatch([]string{done.ID.String(), missing.String()}, out.Skipped)
+
+	_, err = s.usecase.DecideBulk(context.Background(), dto.BulkDecisionInput{
+		SubmissionIDs: []string{pending.ID.String()},
+		Action:        "MAYBE",
+	})
+	sErr, ok := err.(status.Error)
+	s.True(ok && sErr.StatusCode == http.StatusBadRequest, err)
+}
+
+func (s *SubmissionUsecaseSuite) TestRejudge() {
+	task := domain.Task{ID: uuid.New(), Stage: domain.StageAvailable}
+	revision := domain.TaskRevision{ID: uuid.New()}
+	userA, userB := uuid.New(), uuid.New()
+
+	// Ordered by timestamp desc.
+	approved := []domain.Submission{
+		{ID: uuid.New(), UserID: userA, Repository: "a/repo", CommitHash: "0123456"},
+		{ID: uuid.New(), UserID: userB, Repository: "b/repo", CommitHash: "0123456"},
+		{ID: uuid.New(), UserID: userA, Repository: "a/repo", CommitHash: "abcdef0"},
+	}
+
+	testcases := []struct {
+		desc      string
+		in        dto.RejudgeInput
+		setup     func()
+		wantCount int
+		checkErr  func(err error) bool
+	}{
+		{
+			desc: "latest per user",
+			in:   dto.RejudgeInput{LatestPerUser: true},
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), task.ID).Return(task, nil)
+				s.mock.submissionRepository.EXPECT().
+					FetchAllApproved(gomock.Any(), task.ID, nil, nil).Return(approved, nil)
+				s.mock.revisionRepository.EXPECT().
+					FetchLatest(gomock.Any(), task.ID).Return(revision, nil)
+
+				for _, original := range approved[:2] {
+					s.mock.submissionRepository.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Do(func(_ context.Context, rerun domain.Submission) {
+							s.Equal(original.ID, *rerun.RejudgeOf)
+							s.Equal(original.CommitHash, rerun.CommitHash)
+							s.Equal(revision.ID, *rerun.RevisionID)
+							s.False(rerun.IsDone)
+						}).
+						Return(nil)
+				}
+				s.mock.eventRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+			wantCount: 2,
+			checkErr:  func(err error) bool { return err == nil },
+		},
+		{
+			desc: "task not available",
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), task.ID).Return(domain.Task{Stage: domain.StageFixing}, nil)
+			},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusForbidden
+			},
+		},
+		{
+			desc: "invalid range",
+			in: dto.RejudgeInput{
+				From: func() *time.Time { t := time.Now(); return &t }(),
+				To:   func() *time.Time { t := time.Now().Add(-time.Hour); return &t }(),
+			},
+			setup: func() {},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusBadRequest
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			tc.setup()
+
+			tc.in.IDInput = dto.IDInput{ID: task.ID.String()}
+
+			out, err := s.usecase.Rejudge(context.Background(), tc.in)
+			s.True(tc.checkErr(err), err)
+			if err == nil {
+				s.Equal(tc.wantCount, out.Count)
+			}
+		})
+	}
+}
+
 func (s *SubmissionUsecaseSuite) TestCancel() {
 	testUser := auth.Payload{
 		UserID: uuid.New(),
@@ -240,10 +764,15 @@ func (s *SubmissionUsecaseSuite) TestCancel() {
 	s.Require().NotEqual(testUser.UserID, otherUser.UserID)
 
 	doneSubmission := domain.Submission{IsDone: true}
+	cancelledSubmission := domain.Submission{IsDone: true, IsCancelled: true}
 	userSubmission := domain.Submission{
 		UserID: testUser.UserID,
 		IsDone: false,
 	}
+	runningSubmission := domain.Submission{
+		UserID: testUser.UserID,
+		IsDone: true,
+	}
 
 	testcases := []struct {
 		desc     string
@@ -305,6 +834,27 @@ func (s *SubmissionUsecaseSuite) TestCancel() {
 				return ok && sErr.StatusCode == http.StatusNotFound
 			},
 		},
+		{
+			desc:     "success (approved and running)",
+			userInfo: testUser,
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					ExistsByID(gomock.Any(), gomock.Any()).Return(true, nil)
+				s.mock.submissionRepository.EXPECT().
+					FetchByID(gomock.Any(), gomock.Any()).Return(runningSubmission, nil)
+				s.mock.eventRepository.EXPECT().
+					FetchAllBySubmissionID(gomock.Any(), gomock.Any()).Return([]domain.Event{
+					{Kind: domain.KindSubmit},
+					{Kind: domain.KindApprove},
+					{Kind: domain.KindBuildStart},
+				}, nil)
+				s.mock.submissionRepository.EXPECT().
+					Update(gomock.Any(), gomock.Any()).Return(nil)
+				s.mock.eventPublisher.EXPECT().
+					Publish(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			checkErr: func(err error) bool { return err == nil },
+		},
 		{
 			desc: "submission done",
 			setup: func() {
@@ -312,6 +862,25 @@ func (s *SubmissionUsecaseSuite) TestCancel() {
 					ExistsByID(gomock.Any(), gomock.Any()).Return(true, nil)
 				s.mock.submissionRepository.EXPECT().
 					FetchByID(gomock.Any(), gomock.Any()).Return(doneSubmission, nil)
+				s.mock.eventRepository.EXPECT().
+					FetchAllBySubmissionID(gomock.Any(), gomock.Any()).Return([]domain.Event{
+					{Kind: domain.KindSubmit},
+					{Kind: domain.KindApprove},
+					{Kind: domain.KindTestSuccess},
+				}, nil)
+			},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusForbidden
+			},
+		},
+		{
+			desc: "submission cancelled",
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					ExistsByID(gomock.Any(), gomock.Any()).Return(true, nil)
+				s.mock.submissionRepository.EXPECT().
+					FetchByID(gomock.Any(), gomock.Any()).Return(cancelledSubmission, nil)
 			},
 			checkErr: func(err error) bool {
 				sErr, ok := err.(status.Error)
@@ -350,3 +919,65 @@ func (s *SubmissionUsecaseSuite) TestCancel() {
 		})
 	}
 }
+
+func (s *SubmissionUsecaseSuite) TestGetLeaderboard() {
+	var (
+		alice = &domain.User{ID: uuid.New(), Username: "alice", Role: domain.RoleMember}
+		bob   = &domain.User{ID: uuid.New(), Username: "bob", Role: domain.RoleMember}
+
+		high = uint64(200)
+		low  = uint64(100)
+	)
+
+	// Ordered by score desc, then timestamp asc.
+	scored := []domain.Submission{
+		{ID: uuid.New(), Score: &high, UserID: alice.ID, User: alice},
+		{ID: uuid.New(), Score: &high, UserID: bob.ID, User: bob},
+		{ID: uuid.New(), Score: &low, UserID: alice.ID, User: alice},
+	}
+
+	testcases := []struct {
+		desc     string
+		setup    func()
+		checkErr func(err error) bool
+	}{
+		{
+			desc: "success",
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					ExistsByID(gomock.Any(), gomock.Any()).Return(true, nil)
+				s.mock.submissionRepository.EXPECT().
+					FetchAllScored(gomock.Any(), gomock.Any()).Return(scored, nil)
+			},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc: "task does not exist",
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					ExistsByID(gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusNotFound
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			tc.setup()
+
+			out, err := s.usecase.GetLeaderboard(context.Background(), dto.IDInput{ID: uuid.Nil.String()})
+			s.True(tc.checkErr(err), err)
+			if err == nil {
+				// Only the best submission of each user is listed.
+				s.Require().Len(*out, 2)
+				s.Equal(scored[0].ID.String(), (*out)[0].SubmissionID)
+				s.Equal(1, (*out)[0].Rank)
+				s.Equal(scored[1].ID.String(), (*out)[1].SubmissionID)
+				s.Equal(2, (*out)[1].Rank)
+			}
+		})
+	}
+}
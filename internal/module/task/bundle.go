@@ -0,0 +1,118 @@
+package task_module
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/validator"
+)
+
+// bundleVersion is the version of bundle format this server produces and accepts.
+const bundleVersion = 1
+
+const (
+	problemUnsupportedVersion = "UNSUPPORTED_VERSION"
+	problemInvalidSectionType = "INVALID_SECTION_TYPE"
+	problemTooManySections    = "TOO_MANY_SECTIONS"
+	problemDuplicateResource  = "DUPLICATE_RESOURCE"
+)
+
+// fromBundle converts bundle into a new draft task with its sections and resources.
+func fromBundle(bundle dto.TaskBundle) (domain.Task, []domain.Section, []domain.Resource) {
+	task := domain.Task{
+		ID:          uuid.New(),
+		Title:       bundle.Task.Title,
+		Description: bundle.Task.Description,
+		Stage:       domain.StageDraft,
+	}
+
+	sections := make([]domain.Section, len(bundle.Sections))
+	for idx, section := range bundle.Sections {
+		sections[idx] = domain.Section{
+			ID:          uuid.New(),
+			Title:       section.Title,
+			Description: section.Description,
+			Index:       uint8(idx),
+			RPM:         section.RPM,
+			Type:        domain.SectionType(section.Type),
+			Example:     section.Example,
+			TaskID:      task.ID,
+		}
+	}
+
+	resources := make([]domain.Resource, len(bundle.Resources))
+	for idx, resource := range bundle.Resources {
+		resources[idx] = domain.Resource{
+			Image:     resource.Image,
+			Name:      resource.Name,
+			Port:      resource.Port,
+			CPU:       resource.CPU,
+			Memory:    resource.Memory,
+			IsPrimary: resource.IsPrimary,
+			TaskID:    task.ID,
+		}
+	}
+
+	return task, sections, resources
+}
+
+// validateBundle returns all problems preventing the bundle from being imported.
+// Since imported task is a draft, it doesn't have to be complete (e.g. no sections).
+func validateBundle(bundle dto.TaskBundle) []dto.ReadinessProblem {
+	problems := make([]dto.ReadinessProblem, 0)
+
+	if bundle.Version != bundleVersion {
+		problems = append(problems, dto.ReadinessProblem{
+			Code:    problemUnsupportedVersion,
+			Message: fmt.Sprintf("bundle version should be %d, given: %d", bundleVersion, bundle.Version),
+		})
+	}
+
+	if len(bundle.Sections) > math.MaxUint8+1 {
+		problems = append(problems, dto.ReadinessProblem{
+			Code:    problemTooManySections,
+			Message: fmt.Sprintf("task can have at most %d sections", math.MaxUint8+1),
+		})
+	}
+
+	for idx, section := range bundle.Sections {
+		if !validator.SectionTypeValid(domain.SectionType(section.Type)) {
+			problems = append(problems, dto.ReadinessProblem{
+				Code:    problemInvalidSectionType,
+				Message: fmt.Sprintf("section type %q is invalid", section.Type),
+				Target:  fmt.Sprintf("sections[%d]", idx),
+			})
+		}
+	}
+
+	names := make(map[string]bool, len(bundle.Resources))
+	for _, resource := range bundle.Resources {
+		if names[resource.Name] {
+			problems = append(problems, dto.ReadinessProblem{
+				Code:    problemDuplicateResource,
+				Message: "resource name should be unique",
+				Target:  resource.Name,
+			})
+		}
+		names[resource.Name] = true
+	}
+
+	_, sections, resources := fromBundle(bundle)
+
+	for _, problem := range validateReadiness(resources, sections) {
+		switch problem.Code {
+		case problemNoPrimary, problemNoSection:
+			// Draft can be completed after import.
+			continue
+		case problemInvalidRPM:
+			// Section ID is generated on conversion. Not meaningful to users.
+			problem.Target = ""
+		}
+		problems = append(problems, problem)
+	}
+
+	return problems
+}
@@ -0,0 +1,85 @@
+package task_module
+
+import (
+	"testing"
+
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBundle(t *testing.T) {
+	valid := dto.TaskBundle{
+		Version: bundleVersion,
+		Task:    dto.TaskInput{Title: "title", Description: "description"},
+		Sections: []dto.BundleSection{
+			{Title: "scenario", Type: string(domain.TypeScenario)},
+			{Title: "load", Type: string(domain.TypeLoad), RPM: 60},
+		},
+		Resources: []dto.BundleResource{
+			{Name: "app", Image: "app:latest", Port: 8080, IsPrimary: true},
+		},
+	}
+
+	testcases := []struct {
+		desc     string
+		modify   func(b *dto.TaskBundle)
+		expected []string
+	}{
+		{
+			desc:     "valid",
+			modify:   func(b *dto.TaskBundle) {},
+			expected: []string{},
+		},
+		{
+			desc: "incomplete draft",
+			modify: func(b *dto.TaskBundle) {
+				b.Sections = nil
+				b.Resources = nil
+			},
+			expected: []string{},
+		},
+		{
+			desc: "unsupported version",
+			modify: func(b *dto.TaskBundle) {
+				b.Version = bundleVersion + 1
+			},
+			expected: []string{problemUnsupportedVersion},
+		},
+		{
+			desc: "invalid sections",
+			modify: func(b *dto.TaskBundle) {
+				b.Sections = []dto.BundleSection{
+					{Title: "unknown", Type: "UNKNOWN"},
+					{Title: "load", Type: string(domain.TypeLoad)},
+				}
+			},
+			expected: []string{problemInvalidSectionType, problemInvalidRPM},
+		},
+		{
+			desc: "duplicate resource",
+			modify: func(b *dto.TaskBundle) {
+				b.Resources = append(b.Resources, dto.BundleResource{Name: "app", Image: "app", Port: 9090})
+			},
+			expected: []string{problemDuplicateResource},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			bundle := valid
+			bundle.Sections = append([]dto.BundleSection(nil), valid.Sections...)
+			bundle.Resources = append([]dto.BundleResource(nil), valid.Resources...)
+			tc.modify(&bundle)
+
+			problems := validateBundle(bundle)
+
+			codes := make([]string, len(problems))
+			for i, problem := range problems {
+				codes[i] = problem.Code
+			}
+
+			assert.Equal(t, tc.expected, codes)
+		})
+	}
+}
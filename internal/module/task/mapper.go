@@ -28,5 +28,55 @@ func toTaskOutput(task domain.Task) *dto.TaskOutput {
 		Title:       task.Title,
 		Description: task.Description,
 		Stage:       string(task.Stage),
+		ApprovalPolicy: dto.ApprovalPolicy{
+			Rule:        string(task.ApprovalPolicy.Rule),
+			AllowedOrgs: task.ApprovalPolicy.AllowedOrgs,
+		},
+		SubmissionLimit: dto.SubmissionLimit{
+			Hourly: task.SubmissionLimit.Hourly,
+			Daily:  task.SubmissionLimit.Daily,
+		},
 	}
 }
+
+func toReadinessOutput(problems []dto.ReadinessProblem) *dto.ReadinessOutput {
+	return &dto.ReadinessOutput{
+		Ready:    len(problems) == 0,
+		Problems: problems,
+	}
+}
+
+func toTaskBundle(task domain.Task, sections []domain.Section, resources []domain.Resource) *dto.TaskBundle {
+	bundle := &dto.TaskBundle{
+		Version: bundleVersion,
+		Task: dto.TaskInput{
+			Title:       task.Title,
+			Description: task.Description,
+		},
+		Sections:  make([]dto.BundleSection, len(sections)),
+		Resources: make([]dto.BundleResource, len(resources)),
+	}
+
+	for i, section := range sections {
+		bundle.Sections[i] = dto.BundleSection{
+			Title:       section.Title,
+			Description: section.Description,
+			Type:        string(section.Type),
+			RPM:         section.RPM,
+			Example:     section.Example,
+		}
+	}
+
+	for i, resource := range resources {
+		bundle.Resources[i] = dto.BundleResource{
+			Image:     resource.Image,
+			Name:      resource.Name,
+			Port:      resource.Port,
+			CPU:       resource.CPU,
+			Memory:    resource.Memory,
+			IsPrimary: resource.IsPrimary,
+		}
+	}
+
+	return bundle
+}
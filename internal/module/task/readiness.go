@@ -0,0 +1,99 @@
+package task_module
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+)
+
+const (
+	problemNoPrimary       = "NO_PRIMARY_RESOURCE"
+	problemMultiplePrimary = "MULTIPLE_PRIMARY_RESOURCES"
+	problemDuplicatePort   = "DUPLICATE_PORT"
+	problemInvalidImage    = "INVALID_IMAGE"
+	problemNoSection       = "NO_SECTION"
+	problemInvalidRPM      = "INVALID_RPM"
+)
+
+// imageRefPattern matches image references like "nginx", "nginx:1.25",
+// "ghcr.io/owner/name:tag" or "name@sha256:<digest>".
+// It is a simplified form of the grammar used by docker.
+var imageRefPattern = regexp.MustCompile(
+	`^` +
+		// Optional registry host with port.
+		`(?:(?:[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)*(?::[0-9]+)?/)?` +
+		// Path components.
+		`[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*` +
+		// Optional tag.
+		`(?::[\w][\w.-]{0,127})?` +
+		// Optional digest.
+		`(?:@[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,})?` +
+		`$`,
+)
+
+// validateReadiness returns all problems preventing the task from being available.
+// It returns empty slice if the task is ready.
+func validateReadiness(resources []domain.Resource, sections []domain.Section) []dto.ReadinessProblem {
+	problems := make([]dto.ReadinessProblem, 0)
+
+	primaries := 0
+	ports := make(map[uint16]string)
+
+	for _, resource := range resources {
+		if resource.IsPrimary {
+			primaries++
+		}
+
+		if other, ok := ports[resource.Port]; ok {
+			problems = append(problems, dto.ReadinessProblem{
+				Code:    problemDuplicatePort,
+				Message: fmt.Sprintf("port %d is already used by resource %s", resource.Port, other),
+				Target:  resource.Name,
+			})
+		} else {
+			ports[resource.Port] = resource.Name
+		}
+
+		if !imageRefPattern.MatchString(resource.Image) {
+			problems = append(problems, dto.ReadinessProblem{
+				Code:    problemInvalidImage,
+				Message: fmt.Sprintf("image reference %q is invalid", resource.Image),
+				Target:  resource.Name,
+			})
+		}
+	}
+
+	switch {
+	case primaries == 0:
+		problems = append(problems, dto.ReadinessProblem{
+			Code:    problemNoPrimary,
+			Message: "task should have one primary resource",
+		})
+	case primaries > 1:
+		problems = append(problems, dto.ReadinessProblem{
+			Code:    problemMultiplePrimary,
+			Message: fmt.Sprintf("task should have one primary resource, but has %d", primaries),
+		})
+	}
+
+	if len(sections) == 0 {
+		problems = append(problems, dto.ReadinessProblem{
+			Code:    problemNoSection,
+			Message: "task should have at least one section",
+		})
+	}
+
+	for _, section := range sections {
+		if section.Type == domain.TypeLoad && section.RPM == 0 {
+			problems = append(problems, dto.ReadinessProblem{
+				Code:    problemInvalidRPM,
+				Message: "load section should have positive rpm",
+				Target:  section.ID.String(),
+			})
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,67 @@
+package task_module
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/oneee-playground/r2d2-api-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateReadiness(t *testing.T) {
+	primary := domain.Resource{Name: "app", Image: "ghcr.io/owner/app:latest", Port: 8080, IsPrimary: true}
+	db := domain.Resource{Name: "db", Image: "mysql:8", Port: 3306}
+	scenario := domain.Section{ID: uuid.New(), Type: domain.TypeScenario}
+	load := domain.Section{ID: uuid.New(), Type: domain.TypeLoad, RPM: 60}
+
+	testcases := []struct {
+		desc      string
+		resources []domain.Resource
+		sections  []domain.Section
+		expected  []string
+	}{
+		{
+			desc:      "ready",
+			resources: []domain.Resource{primary, db},
+			sections:  []domain.Section{scenario, load},
+			expected:  []string{},
+		},
+		{
+			desc:      "empty task",
+			resources: nil,
+			sections:  nil,
+			expected:  []string{problemNoPrimary, problemNoSection},
+		},
+		{
+			desc: "multiple primary with same port",
+			resources: []domain.Resource{
+				primary,
+				{Name: "app2", Image: "app", Port: 8080, IsPrimary: true},
+			},
+			sections: []domain.Section{scenario},
+			expected: []string{problemDuplicatePort, problemMultiplePrimary},
+		},
+		{
+			desc: "invalid image and rpm",
+			resources: []domain.Resource{
+				primary,
+				{Name: "broken", Image: "Not A/Image::", Port: 9000},
+			},
+			sections: []domain.Section{{ID: uuid.New(), Type: domain.TypeLoad, RPM: 0}},
+			expected: []string{problemInvalidImage, problemInvalidRPM},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			problems := validateReadiness(tc.resources, tc.sections)
+
+			codes := make([]string, len(problems))
+			for i, problem := range problems {
+				codes[i] = problem.Code
+			}
+
+			assert.Equal(t, tc.expected, codes)
+		})
+	}
+}
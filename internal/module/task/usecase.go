@@ -3,27 +3,47 @@ package task_module
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/oneee-playground/r2d2-api-server/internal/domain"
 	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
 	"github.com/oneee-playground/r2d2-api-server/internal/global/status"
 	"github.com/oneee-playground/r2d2-api-server/internal/global/tx"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/validator"
 	"github.com/pkg/errors"
 )
 
+// cancelOnFixingMessage is sent to users whose submission is cancelled by fixing the task.
+const cancelOnFixingMessage = "the task is being fixed. please submit again after it is available"
+
 type taskUsecase struct {
 	lock tx.Locker
 
-	taskRepository domain.TaskRepository
+	taskRepository       domain.TaskRepository
+	submissionRepository domain.SubmissionRepository
+	sectionRepository    domain.SectionRepository
+	resourceRepository   domain.ResourceRepository
+	revisionRepository   domain.TaskRevisionRepository
+	eventPublisher       event.Publisher
 }
 
 var _ domain.TaskUsecase = (*taskUsecase)(nil)
 
-func NewTaskUsecase(tr domain.TaskRepository, l tx.Locker) *taskUsecase {
+func NewTaskUsecase(
+	tr domain.TaskRepository, sur domain.SubmissionRepository,
+	ser domain.SectionRepository, rr domain.ResourceRepository,
+	trr domain.TaskRevisionRepository, ep event.Publisher, l tx.Locker,
+) *taskUsecase {
 	return &taskUsecase{
-		taskRepository: tr,
-		lock:           l,
+		taskRepository:       tr,
+		submissionRepository: sur,
+		sectionRepository:    ser,
+		resourceRepository:   rr,
+		revisionRepository:   trr,
+		eventPublisher:       ep,
+		lock:                 l,
 	}
 }
 
@@ -88,12 +108,76 @@ func (u *taskUsecase) UpdateTask(ctx context.Context, in dto.UpdateTaskInput) (e
 	return nil
 }
 
+func (u *taskUsecase) SetApprovalPolicy(ctx context.Context, in dto.ApprovalPolicyInput) (err error) {
+	taskID := uuid.MustParse(in.ID)
+
+	rule := domain.ApprovalRule(in.Rule)
+	if !validator.ApprovalRuleValid(rule) {
+		return status.NewErr(http.StatusBadRequest, "invalid approval rule")
+	}
+
+	if rule == domain.RuleAllowedOrgs && len(in.AllowedOrgs) == 0 {
+		return status.NewErr(http.StatusBadRequest, "allowed orgs are required for the rule")
+	}
+
+	task, err := u.taskRepository.FetchByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return status.NewErr(http.StatusNotFound, err.Error())
+		}
+
+		return errors.Wrap(err, "fetching task by id")
+	}
+
+	task.ApprovalPolicy = domain.ApprovalPolicy{Rule: rule}
+	if rule == domain.RuleAllowedOrgs {
+		task.ApprovalPolicy.AllowedOrgs = in.AllowedOrgs
+	}
+
+	if err := u.taskRepository.Update(ctx, task); err != nil {
+		return errors.Wrap(err, "updating task")
+	}
+
+	return nil
+}
+
+func (u *taskUsecase) SetSubmissionLimit(ctx context.Context, in dto.SubmissionLimitInput) (err error) {
+	taskID := uuid.MustParse(in.ID)
+
+	task, err := u.taskRepository.FetchByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return status.NewErr(http.StatusNotFound, err.Error())
+		}
+
+		return errors.Wrap(err, "fetching task by id")
+	}
+
+	task.SubmissionLimit = domain.SubmissionLimit{
+		Hourly: in.Hourly,
+		Daily:  in.Daily,
+	}
+
+	if err := u.taskRepository.Update(ctx, task); err != nil {
+		return errors.Wrap(err, "updating task")
+	}
+
+	return nil
+}
+
 func (u *taskUsecase) ChangeStage(ctx context.Context, in dto.TaskStageInput) (err error) {
 	taskID := uuid.MustParse(in.ID)
 
 	ctx, err = tx.NewAtomic(ctx, tx.AtomicOpts{
-		ReadOnly:    false,
-		DataSources: []any{u.taskRepository},
+		ReadOnly: false,
+		DataSources: []any{
+			u.taskRepository,
+			u.submissionRepository,
+			u.sectionRepository,
+			u.resourceRepository,
+			u.revisionRepository,
+			u.eventPublisher,
+		},
 	})
 	if err != nil {
 		return errors.Wrap(err, "starting atomic transaction")
@@ -123,10 +207,22 @@ func (u *taskUsecase) ChangeStage(ctx context.Context, in dto.TaskStageInput) (e
 	case domain.StageDraft:
 		return status.NewErr(http.StatusForbidden, "cannot go back to draft")
 	case domain.StageAvailable:
-		// TODO: need to validate that task has at least and at most one primary resource.
+		resources, sections, err := u.fetchDefinition(ctx, taskID)
+		if err != nil {
+			return err
+		}
+
+		if problems := validateReadiness(resources, sections); len(problems) > 0 {
+			return status.NewErrWithDetails(http.StatusUnprocessableEntity, "task is not ready", problems)
+		}
+
+		if err := u.createRevision(ctx, taskID, resources, sections); err != nil {
+			return err
+		}
 	case domain.StageFixing:
-		// TODO: Publish a event to cancel all running or queued submissions.
-		_ = 1 + 1
+		if err := u.cancelUndoneSubmissions(ctx, taskID); err != nil {
+			return err
+		}
 	}
 
 	task.Stage = stage
@@ -137,3 +233,242 @@ func (u *taskUsecase) ChangeStage(ctx context.Context, in dto.TaskStageInput) (e
 
 	return nil
 }
+
+func (u *taskUsecase) GetReadiness(ctx context.Context, in dto.IDInput) (out *dto.ReadinessOutput, err error) {
+	taskID := uuid.MustParse(in.ID)
+
+	exists, err := u.taskRepository.ExistsByID(ctx, taskID)
+	if err != nil {
+		return nil, errors.Wrap(err, "checking task exists")
+	}
+
+	if !exists {
+		return nil, status.NewErr(http.StatusNotFound, domain.ErrTaskNotFound.Error())
+	}
+
+	resources, sections, err := u.fetchDefinition(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toReadinessOutput(validateReadiness(resources, sections)), nil
+}
+
+func (u *taskUsecase) Export(ctx context.Context, in dto.IDInput) (out *dto.TaskBundle, err error) {
+	taskID := uuid.MustParse(in.ID)
+
+	task, err := u.taskRepository.FetchByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return nil, status.NewErr(http.StatusNotFound, err.Error())
+		}
+
+		return nil, errors.Wrap(err, "fetching task by id")
+	}
+
+	resources, sections, err := u.fetchDefinition(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTaskBundle(task, sections, resources), nil
+}
+
+func (u *taskUsecase) Import(ctx context.Context, in dto.TaskBundle) (out *dto.IDOutput, err error) {
+	if problems := validateBundle(in); len(problems) > 0 {
+		return nil, status.NewErrWithDetails(http.StatusUnprocessableEntity, "invalid bundle", problems)
+	}
+
+	ctx, err = tx.NewAtomic(ctx, tx.AtomicOpts{
+		ReadOnly: false,
+		DataSources: []any{
+			u.taskRepository,
+			u.sectionRepository,
+			u.resourceRepository,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "starting atomic transaction")
+	}
+	defer tx.Evaluate(ctx, &err)
+
+	task, sections, resources := fromBundle(in)
+
+	if err := u.taskRepository.Create(ctx, task); err != nil {
+		return nil, errors.Wrap(err, "creating task")
+	}
+
+	for _, section := range sections {
+		if err := u.sectionRepository.Create(ctx, section); err != nil {
+			return nil, errors.Wrap(err, "creating section")
+		}
+	}
+
+	for _, resource := range resources {
+		if err := u.resourceRepository.Create(ctx, resource); err != nil {
+			return nil, errors.Wrap(err, "creating resource")
+		}
+	}
+
+	return toIDOutput(task), nil
+}
+
+func (u *taskUsecase) Clone(ctx context.Context, in dto.CloneTaskInput) (out *dto.IDOutput, err error) {
+	taskID := uuid.MustParse(in.ID)
+
+	ctx, err = tx.NewAtomic(ctx, tx.AtomicOpts{
+		ReadOnly: false,
+		DataSources: []any{
+			u.taskRepository,
+			u.sectionRepository,
+			u.resourceRepository,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "starting atomic transaction")
+	}
+	defer tx.Evaluate(ctx, &err)
+
+	// Source task should not change while copying.
+	ctx, release, err := u.lock.Acquire(ctx, "task", taskID.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "acquiring lock")
+	}
+	defer release()
+
+	task, err := u.taskRepository.FetchByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return nil, status.NewErr(http.StatusNotFound, err.Error())
+		}
+
+		return nil, errors.Wrap(err, "fetching task by id")
+	}
+
+	resources, sections, err := u.fetchDefinition(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := domain.Task{
+		ID:              uuid.New(),
+		Title:           task.Title,
+		Description:     task.Description,
+		Stage:           domain.StageDraft,
+		ApprovalPolicy:  task.ApprovalPolicy,
+		SubmissionLimit: task.SubmissionLimit,
+	}
+
+	if in.Title != "" {
+		clone.Title = in.Title
+	}
+
+	if err := u.taskRepository.Create(ctx, clone); err != nil {
+		return nil, errors.Wrap(err, "creating task")
+	}
+
+	for _, section := range sections {
+		section.ID = uuid.New()
+		section.TaskID = clone.ID
+
+		if err := u.sectionRepository.Create(ctx, section); err != nil {
+			return nil, errors.Wrap(err, "creating section")
+		}
+	}
+
+	for _, resource := range resources {
+		resource.TaskID = clone.ID
+
+		if err := u.resourceRepository.Create(ctx, resource); err != nil {
+			return nil, errors.Wrap(err, "creating resource")
+		}
+	}
+
+	return toIDOutput(clone), nil
+}
+
+// fetchDefinition fetches resources and sections (with content) of the task.
+func (u *taskUsecase) fetchDefinition(ctx context.Context, taskID uuid.UUID) ([]domain.Resource, []domain.Section, error) {
+	resources, err := u.resourceRepository.FetchAllByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "fetching resources")
+	}
+
+	sections, err := u.sectionRepository.FetchAllByTaskID(ctx, taskID, domain.FetchSectionsOption{IncludeContent: true})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "fetching sections")
+	}
+
+	return resources, sections, nil
+}
+
+// createRevision snapshots given definition as the next revision of the task.
+func (u *taskUsecase) createRevision(
+	ctx context.Context, taskID uuid.UUID,
+	resources []domain.Resource, sections []domain.Section,
+) error {
+	number := uint32(1)
+
+	latest, err := u.revisionRepository.FetchLatest(ctx, taskID)
+	if err != nil && !errors.Is(err, domain.ErrRevisionNotFound) {
+		return errors.Wrap(err, "fetching latest revision")
+	}
+
+	if err == nil {
+		number = latest.Number + 1
+	}
+
+	revision := domain.TaskRevision{
+		ID:        uuid.New(),
+		Number:    number,
+		CreatedAt: time.Now(),
+		Sections:  sections,
+		Resources: resources,
+		TaskID:    taskID,
+	}
+
+	if err := u.revisionRepository.Create(ctx, revision); err != nil {
+		return errors.Wrap(err, "creating revision")
+	}
+
+	return nil
+}
+
+// cancelUndoneSubmissions marks all undone submissions of the task as cancelled,
+// and publishes cancel events for them. Approved submissions still running are cancelled too.
+// Since events are published with outbox in the same transaction,
+// either everything is committed (and delivered eventually) or nothing is.
+func (u *taskUsecase) cancelUndoneSubmissions(ctx context.Context, taskID uuid.UUID) error {
+	undone, err := u.submissionRepository.FetchAllUndone(ctx, taskID)
+	if err != nil {
+		return errors.Wrap(err, "fetching undone submissions")
+	}
+
+	running, err := u.submissionRepository.FetchAllRunning(ctx, taskID)
+	if err != nil {
+		return errors.Wrap(err, "fetching running submissions")
+	}
+
+	for _, submission := range append(undone, running...) {
+		submission.IsDone = true
+		submission.IsCancelled = true
+		if err := u.submissionRepository.Update(ctx, submission); err != nil {
+			return errors.Wrap(err, "updating submission")
+		}
+
+		e := event.SubmissionEvent{
+			ID:           uuid.New(),
+			Timestamp:    time.Now(),
+			Kind:         domain.KindCancel,
+			Extra:        cancelOnFixingMessage,
+			SubmissionID: submission.ID,
+			UserID:       submission.UserID,
+		}
+
+		if err := u.eventPublisher.Publish(ctx, event.TopicSubmission, e); err != nil {
+			return errors.Wrap(err, "publishing event")
+		}
+	}
+
+	return nil
+}
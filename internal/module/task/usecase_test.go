@@ -2,6 +2,7 @@ package task_module_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"testing"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/oneee-playground/r2d2-api-server/internal/domain"
 	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/event"
 	"github.com/oneee-playground/r2d2-api-server/internal/global/status"
 	task_module "github.com/oneee-playground/r2d2-api-server/internal/module/task"
 	"github.com/oneee-playground/r2d2-api-server/test/mocks"
@@ -27,7 +29,12 @@ type TaskUsecaseSuite struct {
 
 	ctl  *gomock.Controller
 	mock struct {
-		taskRepository *mocks.MockTaskRepository
+		taskRepository       *mocks.MockTaskRepository
+		submissionRepository *mocks.MockSubmissionRepository
+		sectionRepository    *mocks.MockSectionRepository
+		resourceRepository   *mocks.MockResourceRepository
+		revisionRepository   *mocks.MockTaskRevisionRepository
+		eventPublisher       *mocks.MockPublisher
 	}
 	stub struct {
 		locker *stubs.StubLocker
@@ -37,9 +44,18 @@ type TaskUsecaseSuite struct {
 func (s *TaskUsecaseSuite) SetupTest() {
 	s.ctl = gomock.NewController(s.T())
 	s.mock.taskRepository = mocks.NewMockTaskRepository(s.ctl)
+	s.mock.submissionRepository = mocks.NewMockSubmissionRepository(s.ctl)
+	s.mock.sectionRepository = mocks.NewMockSectionRepository(s.ctl)
+	s.mock.resourceRepository = mocks.NewMockResourceRepository(s.ctl)
+	s.mock.revisionRepository = mocks.NewMockTaskRevisionRepository(s.ctl)
+	s.mock.eventPublisher = mocks.NewMockPublisher(s.ctl)
 	s.stub.locker = stubs.NewStubLocker()
 
-	s.usecase = task_module.NewTaskUsecase(s.mock.taskRepository, s.stub.locker)
+	s.usecase = task_module.NewTaskUsecase(
+		s.mock.taskRepository, s.mock.submissionRepository,
+		s.mock.sectionRepository, s.mock.resourceRepository,
+		s.mock.revisionRepository, s.mock.eventPublisher, s.stub.locker,
+	)
 }
 
 func (s *TaskUsecaseSuite) TestChangeStage() {
@@ -53,35 +69,140 @@ func (s *TaskUsecaseSuite) TestChangeStage() {
 	availableTask := draftTask
 	availableTask.Stage = domain.StageAvailable
 
+	readyResources := []domain.Resource{{Name: "app", Image: "nginx:1.25", Port: 8080, IsPrimary: true}}
+	readySections := []domain.Section{{ID: uuid.New(), Type: domain.TypeScenario}}
+
+	undoneSubmissions := []domain.Submission{
+		{ID: uuid.New(), IsDone: false},
+		{ID: uuid.New(), IsDone: false},
+	}
+	// Approved submissions are done while they are executed.
+	runningSubmission := domain.Submission{ID: uuid.New(), IsDone: true}
+
 	testcases := []struct {
 		desc        string
 		targetStage domain.TaskStage
 		setup       func()
 		checkErr    func(err error) bool
 	}{
-		// TODO: Cover case when input stage is domain.StageFixing
 		{
 			desc:        "draft -> available",
 			targetStage: domain.StageAvailable,
 			setup: func() {
 				s.mock.taskRepository.EXPECT().
 					FetchByID(gomock.Any(), gomock.Any()).Return(draftTask, nil)
+				s.mock.resourceRepository.EXPECT().
+					FetchAllByTaskID(gomock.Any(), gomock.Any()).Return(readyResources, nil)
+				s.mock.sectionRepository.EXPECT().
+					FetchAllByTaskID(gomock.Any(), gomock.Any(), gomock.Any()).Return(readySections, nil)
+				s.mock.revisionRepository.EXPECT().
+					FetchLatest(gomock.Any(), gomock.Any()).Return(domain.TaskRevision{Number: 2}, nil)
+				s.mock.revisionRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, revision domain.TaskRevision) {
+						s.Equal(uint32(3), revision.Number)
+						s.Equal(readySections, revision.Sections)
+						s.Equal(readyResources, revision.Resources)
+					}).
+					Return(nil)
 				s.mock.taskRepository.EXPECT().
 					Update(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			checkErr: func(err error) bool { return err == nil },
 		},
+		{
+			desc:        "draft -> available but not ready",
+			targetStage: domain.StageAvailable,
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), gomock.Any()).Return(draftTask, nil)
+				s.mock.resourceRepository.EXPECT().
+					FetchAllByTaskID(gomock.Any(), gomock.Any()).Return(nil, nil)
+				s.mock.sectionRepository.EXPECT().
+					FetchAllByTaskID(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				if !ok || sErr.StatusCode != http.StatusUnprocessableEntity {
+					return false
+				}
+
+				problems, ok := sErr.Details.([]dto.ReadinessProblem)
+				return ok && len(problems) == 2
+			},
+		},
 		{
 			desc:        "available -> fixing",
 			targetStage: domain.StageFixing,
 			setup: func() {
 				s.mock.taskRepository.EXPECT().
 					FetchByID(gomock.Any(), gomock.Any()).Return(availableTask, nil)
+				s.mock.submissionRepository.EXPECT().
+					FetchAllUndone(gomock.Any(), gomock.Any()).Return(undoneSubmissions, nil)
+				s.mock.submissionRepository.EXPECT().
+					FetchAllRunning(gomock.Any(), gomock.Any()).Return(nil, nil)
+				s.mock.submissionRepository.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, submission domain.Submission) {
+						s.True(submission.IsDone)
+						s.True(submission.IsCancelled)
+					}).
+					Return(nil).Times(len(undoneSubmissions))
+				s.mock.eventPublisher.EXPECT().
+					Publish(gomock.Any(), event.TopicSubmission, gomock.Any()).
+					Do(func(_ context.Context, _ event.Topic, e any) {
+						s.Equal(domain.KindCancel, e.(event.SubmissionEvent).Kind)
+					}).
+					Return(nil).Times(len(undoneSubmissions))
+				s.mock.taskRepository.EXPECT().
+					Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc:        "available -> fixing with running submission",
+			targetStage: domain.StageFixing,
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), gomock.Any()).Return(availableTask, nil)
+				s.mock.submissionRepository.EXPECT().
+					FetchAllUndone(gomock.Any(), gomock.Any()).Return(nil, nil)
+				s.mock.submissionRepository.EXPECT().
+					FetchAllRunning(gomock.Any(), gomock.Any()).Return([]domain.Submission{runningSubmission}, nil)
+				s.mock.submissionRepository.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, submission domain.Submission) {
+						s.Equal(runningSubmission.ID, submission.ID)
+						s.True(submission.IsCancelled)
+					}).
+					Return(nil)
+				s.mock.eventPublisher.EXPECT().
+					Publish(gomock.Any(), event.TopicSubmission, gomock.Any()).
+					Do(func(_ context.Context, _ event.Topic, e any) {
+						s.Equal(domain.KindCancel, e.(event.SubmissionEvent).Kind)
+						s.Equal(runningSubmission.ID, e.(event.SubmissionEvent).SubmissionID)
+					}).
+					Return(nil)
 				s.mock.taskRepository.EXPECT().
 					Update(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			checkErr: func(err error) bool { return err == nil },
 		},
+		{
+			desc:        "available -> fixing but cancelling fails",
+			targetStage: domain.StageFixing,
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), gomock.Any()).Return(availableTask, nil)
+				s.mock.submissionRepository.EXPECT().
+					FetchAllUndone(gomock.Any(), gomock.Any()).Return(undoneSubmissions, nil)
+				s.mock.submissionRepository.EXPECT().
+					FetchAllRunning(gomock.Any(), gomock.Any()).Return(nil, nil)
+				s.mock.submissionRepository.EXPECT().
+					Update(gomock.Any(), gomock.Any()).Return(errors.New("db down"))
+			},
+			checkErr: func(err error) bool { return err != nil },
+		},
 		{
 			desc:        "available -> available",
 			targetStage: domain.StageAvailable,
@@ -124,3 +245,291 @@ func (s *TaskUsecaseSuite) TestChangeStage() {
 		})
 	}
 }
+
+func (s *TaskUsecaseSuite) TestImport() {
+	bundle := dto.TaskBundle{
+		Version: 1,
+		Task:    dto.TaskInput{Title: "title", Description: "description"},
+		Sections: []dto.BundleSection{
+			{Title: "first", Type: string(domain.TypeScenario)},
+			{Title: "second", Type: string(domain.TypeLoad), RPM: 60},
+		},
+		Resources: []dto.BundleResource{
+			{Name: "app", Image: "app:latest", Port: 8080, IsPrimary: true},
+		},
+	}
+
+	invalidBundle := bundle
+	invalidBundle.Version = 0
+
+	testcases := []struct {
+		desc     string
+		bundle   dto.TaskBundle
+		setup    func()
+		checkErr func(err error) bool
+	}{
+		{
+			desc:   "success",
+			bundle: bundle,
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, task domain.Task) {
+						s.Equal(domain.StageDraft, task.Stage)
+					}).
+					Return(nil)
+
+				var index uint8
+				s.mock.sectionRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, section domain.Section) {
+						s.Equal(index, section.Index)
+						index++
+					}).
+					Return(nil).Times(2)
+				s.mock.resourceRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc:   "invalid bundle",
+			bundle: invalidBundle,
+			setup:  func() {},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusUnprocessableEntity
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			tc.setup()
+
+			_, err := s.usecase.Import(context.Background(), tc.bundle)
+			s.True(tc.checkErr(err), err)
+		})
+	}
+}
+
+func (s *TaskUsecaseSuite) TestClone() {
+	task := domain.Task{
+		ID:          uuid.New(),
+		Title:       "title",
+		Description: "description",
+		Stage:       domain.StageAvailable,
+	}
+
+	sections := []domain.Section{
+		{ID: uuid.New(), TaskID: task.ID, Index: 0, Type: domain.TypeScenario},
+		{ID: uuid.New(), TaskID: task.ID, Index: 1, Type: domain.TypeLoad, RPM: 60},
+	}
+	resources := []domain.Resource{
+		{TaskID: task.ID, Name: "app", Image: "app:latest", Port: 8080, IsPrimary: true},
+	}
+
+	testcases := []struct {
+		desc     string
+		in       dto.CloneTaskInput
+		setup    func()
+		checkErr func(err error) bool
+	}{
+		{
+			desc: "success",
+			in: dto.CloneTaskInput{
+				IDInput: dto.IDInput{ID: task.ID.String()},
+				Title:   "new title",
+			},
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), task.ID).Return(task, nil)
+				s.mock.resourceRepository.EXPECT().
+					FetchAllByTaskID(gomock.Any(), task.ID).Return(resources, nil)
+				s.mock.sectionRepository.EXPECT().
+					FetchAllByTaskID(gomock.Any(), task.ID, gomock.Any()).Return(sections, nil)
+
+				var cloneID uuid.UUID
+				s.mock.taskRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, clone domain.Task) {
+						s.NotEqual(task.ID, clone.ID)
+						s.Equal("new title", clone.Title)
+						s.Equal(domain.StageDraft, clone.Stage)
+						cloneID = clone.ID
+					}).
+					Return(nil)
+
+				var index uint8
+				s.mock.sectionRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, section domain.Section) {
+						s.NotEqual(sections[index].ID, section.ID)
+						s.Equal(cloneID, section.TaskID)
+						s.Equal(index, section.Index)
+						index++
+					}).
+					Return(nil).Times(2)
+				s.mock.resourceRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, resource domain.Resource) {
+						s.Equal(cloneID, resource.TaskID)
+					}).
+					Return(nil)
+			},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc: "task not found",
+			in:   dto.CloneTaskInput{IDInput: dto.IDInput{ID: task.ID.String()}},
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), task.ID).Return(domain.Task{}, domain.ErrTaskNotFound)
+			},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusNotFound
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			tc.setup()
+
+			_, err := s.usecase.Clone(context.Background(), tc.in)
+			s.True(tc.checkErr(err), err)
+		})
+	}
+}
+
+func (s *TaskUsecaseSuite) TestSetApprovalPolicy() {
+	task := domain.Task{ID: uuid.New(), Stage: domain.StageAvailable}
+
+	testcases := []struct {
+		desc     string
+		in       dto.ApprovalPolicy
+		setup    func()
+		checkErr func(err error) bool
+	}{
+		{
+			desc: "allowed orgs",
+			in:   dto.ApprovalPolicy{Rule: "ALLOWED_ORGS", AllowedOrgs: []string{"org"}},
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), task.ID).Return(task, nil)
+				s.mock.taskRepository.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, updated domain.Task) {
+						s.Equal(domain.RuleAllowedOrgs, updated.ApprovalPolicy.Rule)
+						s.Equal([]string{"org"}, updated.ApprovalPolicy.AllowedOrgs)
+					}).
+					Return(nil)
+			},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc:  "invalid rule",
+			in:    dto.ApprovalPolicy{Rule: "SOMETIMES"},
+			setup: func() {},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusBadRequest
+			},
+		},
+		{
+			desc:  "allowed orgs without orgs",
+			in:    dto.ApprovalPolicy{Rule: "ALLOWED_ORGS"},
+			setup: func() {},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusBadRequest
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			tc.setup()
+
+			err := s.usecase.SetApprovalPolicy(context.Background(), dto.ApprovalPolicyInput{
+				IDInput:        dto.IDInput{ID: task.ID.String()},
+				ApprovalPolicy: tc.in,
+			})
+			s.True(tc.checkErr(err), err)
+		})
+	}
+}
+
+func (s *TaskUsecaseSuite) TestSetSubmissionLimit() {
+	hourly := uint64(5)
+	limited := domain.Task{
+		ID:              uuid.New(),
+		Stage:           domain.StageAvailable,
+		SubmissionLimit: domain.SubmissionLimit{Hourly: &hourly},
+	}
+
+	testcases := []struct {
+		desc     string
+		in       dto.SubmissionLimit
+		setup    func()
+		checkErr func(err error) bool
+	}{
+		{
+			desc: "override",
+			in:   dto.SubmissionLimit{Hourly: &hourly},
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), limited.ID).Return(domain.Task{ID: limited.ID}, nil)
+				s.mock.taskRepository.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, updated domain.Task) {
+						s.Equal(&hourly, updated.SubmissionLimit.Hourly)
+						s.Nil(updated.SubmissionLimit.Daily)
+					}).
+					Return(nil)
+			},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc: "fall back to global limits",
+			in:   dto.SubmissionLimit{},
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), limited.ID).Return(limited, nil)
+				s.mock.taskRepository.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, updated domain.Task) {
+						s.Nil(updated.SubmissionLimit.Hourly)
+						s.Nil(updated.SubmissionLimit.Daily)
+					}).
+					Return(nil)
+			},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc: "task does not exist",
+			in:   dto.SubmissionLimit{Hourly: &hourly},
+			setup: func() {
+				s.mock.taskRepository.EXPECT().
+					FetchByID(gomock.Any(), limited.ID).Return(domain.Task{}, domain.ErrTaskNotFound)
+			},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusNotFound
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			tc.setup()
+
+			err := s.usecase.SetSubmissionLimit(context.Background(), dto.SubmissionLimitInput{
+				IDInput:         dto.IDInput{ID: limited.ID.String()},
+				SubmissionLimit: tc.in,
+			})
+			s.True(tc.checkErr(err), err)
+		})
+	}
+}
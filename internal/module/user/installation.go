@@ -0,0 +1,19 @@
+package user_module
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=installation.go -destination=../../../test/mocks/installation.go -package=mocks
+
+var (
+	ErrInstallationNotFound = errors.New("installation not found")
+)
+
+type InstallationClient interface {
+	// FetchInstallationOwner returns username of the account which github app is installed on.
+	// It returns ErrInstallationNotFound if there is no such installation of the app.
+	FetchInstallationOwner(ctx context.Context, installationID int64) (string, error)
+}
@@ -2,22 +2,28 @@ package user_module
 
 import (
 	"context"
+	"net/http"
+	"strings"
 
+	"github.com/google/uuid"
 	"github.com/oneee-playground/r2d2-api-server/internal/domain"
 	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
 	"github.com/oneee-playground/r2d2-api-server/internal/global/auth"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/status"
 	"github.com/pkg/errors"
 )
 
 type userUsecase struct {
-	userRepository domain.UserRepository
+	userRepository     domain.UserRepository
+	installationClient InstallationClient
 }
 
 var _ domain.UserUsecase = (*userUsecase)(nil)
 
-func NewUserUsecase(ur domain.UserRepository) *userUsecase {
+func NewUserUsecase(ur domain.UserRepository, ic InstallationClient) *userUsecase {
 	return &userUsecase{
-		userRepository: ur,
+		userRepository:     ur,
+		installationClient: ic,
 	}
 }
 
@@ -32,3 +38,53 @@ func (u *userUsecase) GetSelfInfo(ctx context.Context) (out *dto.UserInfo, err e
 
 	return toUserInfo(user), nil
 }
+
+func (u *userUsecase) SetLimitExemption(ctx context.Context, in dto.LimitExemptionInput) (err error) {
+	user, err := u.userRepository.FetchByID(ctx, uuid.MustParse(in.ID))
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return status.NewErr(http.StatusNotFound, err.Error())
+		}
+
+		return errors.Wrap(err, "fetching user by id")
+	}
+
+	user.LimitExempt = *in.Exempt
+
+	if err := u.userRepository.Update(ctx, user); err != nil {
+		return errors.Wrap(err, "updating user")
+	}
+
+	return nil
+}
+
+func (u *userUsecase) SetInstallation(ctx context.Context, in dto.InstallationInput) (err error) {
+	info := auth.MustExtract(ctx)
+
+	user, err := u.userRepository.FetchByID(ctx, info.UserID)
+	if err != nil {
+		return errors.Wrap(err, "fetching user by id")
+	}
+
+	owner, err := u.installationClient.FetchInstallationOwner(ctx, in.InstallationID)
+	if err != nil {
+		if errors.Is(err, ErrInstallationNotFound) {
+			return status.NewErr(http.StatusNotFound, err.Error())
+		}
+
+		return errors.Wrap(err, "fetching installation owner")
+	}
+
+	// Github usernames are case-insensitive.
+	if !strings.EqualFold(owner, user.Username) {
+		return status.NewErr(http.StatusForbidden, "installation does not belong to the user")
+	}
+
+	user.InstallationID = &in.InstallationID
+
+	if err := u.userRepository.Update(ctx, user); err != nil {
+		return errors.Wrap(err, "updating user")
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package user_module_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -9,6 +10,7 @@ import (
 	"github.com/oneee-playground/r2d2-api-server/internal/domain"
 	"github.com/oneee-playground/r2d2-api-server/internal/domain/dto"
 	"github.com/oneee-playground/r2d2-api-server/internal/global/auth"
+	"github.com/oneee-playground/r2d2-api-server/internal/global/status"
 	user_module "github.com/oneee-playground/r2d2-api-server/internal/module/user"
 	"github.com/oneee-playground/r2d2-api-server/test/mocks"
 	"github.com/stretchr/testify/suite"
@@ -25,15 +27,17 @@ type UserUsecaseSuite struct {
 
 	ctl  *gomock.Controller
 	mock struct {
-		userRepository *mocks.MockUserRepository
+		userRepository     *mocks.MockUserRepository
+		installationClient *mocks.MockInstallationClient
 	}
 }
 
 func (s *UserUsecaseSuite) SetupTest() {
 	s.ctl = gomock.NewController(s.T())
 	s.mock.userRepository = mocks.NewMockUserRepository(s.ctl)
+	s.mock.installationClient = mocks.NewMockInstallationClient(s.ctl)
 
-	s.usecase = user_module.NewUserUsecase(s.mock.userRepository)
+	s.usecase = user_module.NewUserUsecase(s.mock.userRepository, s.mock.installationClient)
 }
 
 func (s *UserUsecaseSuite) TestGetSelfInfo() {
@@ -97,3 +101,113 @@ func (s *UserUsecaseSuite) TestGetSelfInfo() {
 		})
 	}
 }
+
+func (s *UserUsecaseSuite) TestSetLimitExemption() {
+	testUser := domain.User{ID: uuid.New(), Role: domain.RoleMember}
+	exempt := true
+
+	testcases := []struct {
+		desc     string
+		setup    func()
+		checkErr func(err error) bool
+	}{
+		{
+			desc: "success",
+			setup: func() {
+				s.mock.userRepository.EXPECT().
+					FetchByID(gomock.Any(), testUser.ID).Return(testUser, nil)
+				s.mock.userRepository.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, user domain.User) {
+						s.True(user.LimitExempt)
+					}).
+					Return(nil)
+			},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc: "user not found",
+			setup: func() {
+				s.mock.userRepository.EXPECT().
+					FetchByID(gomock.Any(), testUser.ID).Return(domain.User{}, domain.ErrUserNotFound)
+			},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusNotFound
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			tc.setup()
+
+			err := s.usecase.SetLimitExemption(context.Background(), dto.LimitExemptionInput{
+				IDInput: dto.IDInput{ID: testUser.ID.String()},
+				Exempt:  &exempt,
+			})
+			s.True(tc.checkErr(err), err)
+		})
+	}
+}
+
+func (s *UserUsecaseSuite) TestSetInstallation() {
+	testUser := domain.User{ID: uuid.New(), Username: "User", Role: domain.RoleMember}
+	installationID := int64(1)
+
+	testcases := []struct {
+		desc     string
+		setup    func()
+		checkErr func(err error) bool
+	}{
+		{
+			desc: "success",
+			setup: func() {
+				s.mock.installationClient.EXPECT().
+					FetchInstallationOwner(gomock.Any(), installationID).Return("user", nil)
+				s.mock.userRepository.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, user domain.User) {
+						s.Equal(&installationID, user.InstallationID)
+					}).
+					Return(nil)
+			},
+			checkErr: func(err error) bool { return err == nil },
+		},
+		{
+			desc: "installation of others",
+			setup: func() {
+				s.mock.installationClient.EXPECT().
+					FetchInstallationOwner(gomock.Any(), installationID).Return("other", nil)
+			},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusForbidden
+			},
+		},
+		{
+			desc: "installation not found",
+			setup: func() {
+				s.mock.installationClient.EXPECT().
+					FetchInstallationOwner(gomock.Any(), installationID).Return("", user_module.ErrInstallationNotFound)
+			},
+			checkErr: func(err error) bool {
+				sErr, ok := err.(status.Error)
+				return ok && sErr.StatusCode == http.StatusNotFound
+			},
+		},
+	}
+
+	ctx := auth.Inject(context.Background(), auth.Payload{UserID: testUser.ID})
+	for _, tc := range testcases {
+		s.Run(tc.desc, func() {
+			s.mock.userRepository.EXPECT().
+				FetchByID(gomock.Any(), testUser.ID).Return(testUser, nil)
+
+			tc.setup()
+
+			err := s.usecase.SetInstallation(ctx, dto.InstallationInput{InstallationID: installationID})
+			s.True(tc.checkErr(err), err)
+		})
+	}
+}